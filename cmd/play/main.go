@@ -0,0 +1,677 @@
+package main
+
+import (
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "strings"
+
+    "github.com/eiannone/keyboard"
+
+    "github.com/JackRubiralta/Go-Teeko/encoding"
+    "github.com/JackRubiralta/Go-Teeko/solver"
+    "github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// Basic arrow key constants:
+const (
+    KeyArrowUp = iota
+    KeyArrowDown
+    KeyArrowLeft
+    KeyArrowRight
+    KeyEnter
+    KeyUndo
+    KeyRedo
+    KeyOther
+)
+
+// readKey uses eiannone/keyboard to return a simpler integer representing arrow keys, Enter,
+// or the 'u' / 'r' takeback keys.
+func readKey() int {
+    char, key, err := keyboard.GetKey()
+    if err != nil {
+        return KeyOther
+    }
+    switch char {
+    case 'u':
+        return KeyUndo
+    case 'r':
+        return KeyRedo
+    }
+    switch key {
+    case keyboard.KeyArrowUp:
+        return KeyArrowUp
+    case keyboard.KeyArrowDown:
+        return KeyArrowDown
+    case keyboard.KeyArrowLeft:
+        return KeyArrowLeft
+    case keyboard.KeyArrowRight:
+        return KeyArrowRight
+    case keyboard.KeyEnter:
+        return KeyEnter
+    }
+    return KeyOther
+}
+
+// navigateBoard modifies the cursor square based on arrow keys and returns the key pressed.
+func navigateBoard(board *teeko.Board, cursor *teeko.Square) int {
+    x, y := &cursor.X, &cursor.Y
+    key := readKey()
+    switch key {
+    case KeyArrowUp:
+        if *y < board.Height-1 {
+            *y++
+            // Move cursor up visually (2 lines).
+            fmt.Print("\x1b[A\x1b[A")
+        }
+    case KeyArrowDown:
+        if *y > 0 {
+            *y--
+            fmt.Print("\x1b[B\x1b[B")
+        }
+    case KeyArrowLeft:
+        if *x > 0 {
+            *x--
+            // Move cursor ~6 columns left
+            fmt.Print("\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D")
+			
+        }
+    case KeyArrowRight:
+        if *x < board.Width-1 {
+            *x++
+            // Move cursor ~6 columns right
+            fmt.Print("\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C")
+			
+        }
+    }
+    return key
+}
+
+// -------------------------------------------------------------------
+// Minimal helper to move the cursor from "center" (board.Center()) to another square.
+// Because after printing, your code repositions the cursor near the center,
+// we just do little arrow steps to get from the center to the square.
+// -------------------------------------------------------------------
+func moveCursorFromCenterTo(board *teeko.Board, square teeko.Square) {
+    // Starting at center => c3 on a 5x5 board
+    center := board.Center()
+    dx := square.X - center.X
+    dy := square.Y - center.Y
+
+    // If dy > 0 => we need to move up
+    //   because in your code, y=0 is bottom, y=height-1 is top
+    //   so "increasing y" means going up on the board
+    if dy > 0 {
+        for i := 0; i < dy; i++ {
+            fmt.Print("\x1b[A\x1b[A") // same logic as navigateBoard
+        }
+    } else {
+        // negative => we move down
+        for i := 0; i < -dy; i++ {
+            fmt.Print("\x1b[B\x1b[B")
+        }
+    }
+
+    // If dx > 0 => move left or right?
+    // Actually, x=0 is left, x=width-1 is right, so
+    //   if dx>0 => we move right
+    //   if dx<0 => we move left
+    if dx > 0 {
+        for i := 0; i < dx; i++ {
+            fmt.Print("\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C")
+        }
+    } else {
+        for i := 0; i < -dx; i++ {
+            fmt.Print("\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D\x1b[D")
+        }
+    }
+}
+
+// -------------------------------------------------------------------
+// printTeeko prints the board, optionally highlighting a specific bit (for a selected marker).
+// We use "\u001b[46;1m" (cyan background) for the highlighted marker (Black or Red).
+// -------------------------------------------------------------------
+func printTeeko(game teeko.Teeko, selected_marker teeko.Bitboard) {
+    board := game.Rules.Board
+    const vertical_separator = "\u001b[36m|"
+    // 6 characters per column plus the row labels and the closing "|"
+    horizontal_separator := "\u001b[36m" + strings.Repeat("-", 6*(board.Width+1)+1)
+
+    // First, figure out which squares belong to "black" vs "red"
+    black := game.Black()
+    red := game.Red()
+
+    // Compute all squares that the selected_marker can move to (highlight_destinations).
+    // We'll only do this if there is a selected_marker of the side to move;
+    // its neighbour mask minus the occupied squares is exactly that set.
+    var highlight_destinations teeko.Bitboard
+    if teeko.PopCount(selected_marker) == 1 && (selected_marker & game.PositionsOf(game.CurrentPlayer)) != 0 {
+        highlight_destinations = board.Neighbours[board.Index(board.SquareOf(selected_marker))] &^ (black | red)
+    }
+
+    // Now print the board
+    var row int = board.Height
+    for row != 0 {
+        fmt.Print(horizontal_separator, "\n")
+        fmt.Print(vertical_separator, "  ", row, "  ")
+
+        var column int = 1
+        for column <= board.Width {
+            fmt.Print(vertical_separator)
+
+            square_bit := board.SquareBit(teeko.Square{X: column - 1, Y: row - 1})
+
+            // Check if this square is the 'selected_marker'
+            is_selected_marker := (selected_marker & square_bit) != 0
+            // Check if this square is in highlight_destinations
+            is_destination := (highlight_destinations & square_bit) != 0
+
+            // Check if black or red is occupying this square
+            is_black := (black & square_bit) != 0
+            is_red   := (red   & square_bit) != 0
+
+            switch {
+            // 1) Square belongs to black
+            case is_black:
+                if is_selected_marker {
+                    // Highlighted black piece => cyan background
+                    fmt.Print(" \u001b[46;1m \u001b[30;1mB \u001b[0m ")
+                } else {
+                    fmt.Print("  \u001b[30;1mB \u001b[0m ")
+                }
+
+            // 2) Square belongs to red
+            case is_red:
+                if is_selected_marker {
+                    // Highlighted red piece => cyan background
+                    fmt.Print(" \u001b[46;1m \u001b[31;1mR \u001b[0m ")
+                } else {
+                    fmt.Print("  \u001b[31;1mR \u001b[0m ")
+                }
+
+            // 3) Square is empty
+            default:
+                if is_selected_marker {
+                    // If for some reason the selected_marker is on an empty square
+                    // (shouldn't happen if selected_marker is truly a piece),
+                    // we could do a special highlight. Let's keep consistent:
+                    fmt.Print(" \u001b[46;1m \u001b[0m ")
+                } else if is_destination {
+                    // This is one of the squares the selected_marker can move to
+                    // Print a purple "+" to indicate a valid move destination
+                    fmt.Print("  \u001b[35;1m+ \u001b[0m ")
+                } else {
+                    // Normal empty square
+                    fmt.Print("  -  ")
+                }
+            }
+
+            column++
+        }
+        fmt.Print(vertical_separator, "\n")
+        row--
+    }
+
+    // Print the bottom border
+    fmt.Print(horizontal_separator, "\n")
+
+    // Column headers (a, b, c, ... as in the square names)
+    fmt.Print(vertical_separator, "     ")
+    for colIdx := 0; colIdx < board.Width; colIdx++ {
+        fmt.Print(vertical_separator)
+        fmt.Printf("%3s  ", teeko.ColumnName(colIdx))
+    }
+    fmt.Print(vertical_separator, "\n")
+    fmt.Print(horizontal_separator, "\n")
+    fmt.Print("\u001b[0m")
+}
+
+// -------------------------------------------------------------------
+// printBoardWithInfo
+// 1) Clears screen
+// 2) Prints board (no highlight).
+// 3) Prints evaluation and instructions
+// 4) Moves cursor up & right near the center (like your original).
+// -------------------------------------------------------------------
+func printBoardWithInfo(book *solver.Book, game teeko.Teeko, selected_marker teeko.Bitboard) {
+    fmt.Print("\033[H\033[2J") // Clear screen
+
+    // Print board with no highlight
+    printTeeko(game, selected_marker)
+    // Print evaluation
+    // Phase-based instructions
+    var player_text string
+    if game.CurrentPlayer == teeko.BlackToMove {
+        player_text = "\u001b[30;1mBlack\u001b[0m"
+    } else {
+        player_text = "\u001b[31;1mRed\u001b[0m"
+    }
+    score := book.Evaluate(game)
+    switch {
+    case score > 0:
+        // 1..125 => how many more moves until 126
+        fmt.Printf("Current player can force a win in %d moves.", solver.WIN - score)
+    case score < 0:
+        // -125..-1 => how many more moves until opponent hits 126
+        fmt.Printf("Opponent can force a win in %d moves.", solver.WIN + score)
+    default:
+        // score == 0 => no forced result either way
+        fmt.Print("No forced win.")
+    }
+    // Warn (on the same line) about drops/moves that would win the opponent the game
+    opponent := teeko.RedToMove
+    if game.CurrentPlayer == teeko.RedToMove {
+        opponent = teeko.BlackToMove
+    }
+    if threats := game.ThreatMoves(opponent); len(threats) > 0 {
+        fmt.Printf(" \u001b[35;1mOpponent threatens %v\u001b[0m", threats)
+    }
+    fmt.Println()
+
+    if game.Phase() == teeko.DropPhase {
+        markers_left := game.Rules.Markers - game.MarkerCount(game.CurrentPlayer)
+        fmt.Printf("%s, use arrow-keys to pick a drop (%d left); ENTER to confirm; u/r to undo/redo.\n", player_text, markers_left)
+    } else {
+        fmt.Printf("%s, arrow-keys to pick marker & destination; ENTER to confirm; u/r to undo/redo.\n", player_text)
+    }
+
+    // Move cursor up to the center row (~10 lines on a 5x5 board)
+    center := game.Rules.Board.Center()
+    for i := 0; i < 6+2*center.Y; i++ {
+        fmt.Print("\x1b[A")
+    }
+    // Then move right to the center column (~21 columns)
+    for i := 0; i < 9+6*center.X; i++ {
+        fmt.Print("\x1b[C")
+    }
+}
+
+// computerMove plays the book's choice for the side to move; it fails only
+// if the side to move has no legal drop or move at all
+func computerMove(book *solver.Book, record *teeko.Game) error {
+    game := record.Current()
+
+    // If evaluate(game) == 0 => TIE
+    if book.Evaluate(game) == solver.TIE {
+        // We'll do a shallow lookahead approach
+        // Ties between candidates go to the one that leaves us the most threats
+        mover := game.CurrentPlayer
+        if game.Phase() == teeko.DropPhase {
+            var best_drop teeko.Bitboard
+            var best_sum int = -1000000
+            var best_threats int = -1
+
+            // Consider each drop
+            var drops, opponent_moves teeko.ActionList
+            game.GenerateDrops(&drops)
+            for _, drop := range drops.Slice() {
+                child_game := game
+                child_game.DropMarker(drop)
+
+				if book.Evaluate(child_game) != solver.TIE {
+					continue
+				}
+
+                // Now it's opponent's turn
+                child_game.GenerateMoves(&opponent_moves)
+                var sum_win_moves int = 0
+                for _, opponent_move := range opponent_moves.Slice() {
+                    opponent_child := child_game
+                    opponent_child.MoveMarker(opponent_move)
+
+                    opponent_score := book.Evaluate(opponent_child)
+
+                    // If opponent_score < 0 => opponent is losing
+                    if opponent_score < 0 {
+                        // Add (WIN + negative_score)
+                        sum_win_moves += int(solver.WIN - opponent_score)
+                    }
+                }
+                threats := teeko.PopCount(child_game.ThreatSquares(mover))
+                if sum_win_moves > best_sum || (sum_win_moves == best_sum && threats > best_threats) {
+                    best_sum = sum_win_moves
+                    best_threats = threats
+                    best_drop = drop
+                }
+				
+            }
+
+            // Perform best_drop
+            return playOrFallBack(record, game, best_drop)
+
+        } else {
+            // MovePhase
+            var best_move_local teeko.Bitboard
+            var best_sum int = -1000000
+            var best_threats int = -1
+
+            var moves, opponent_moves teeko.ActionList
+            game.GenerateMoves(&moves)
+            for _, move_candidate := range moves.Slice() {
+                child_game := game
+                child_game.MoveMarker(move_candidate)
+				
+				if book.Evaluate(child_game) != solver.TIE {
+					continue
+				}
+
+                child_game.GenerateMoves(&opponent_moves)
+                var sum_win_moves int = 0
+                for _, opponent_move := range opponent_moves.Slice() {
+                    opponent_child := child_game
+                    opponent_child.MoveMarker(opponent_move)
+
+                    opponent_score := book.Evaluate(opponent_child)
+                    if opponent_score > 0 {
+                        sum_win_moves += int(solver.WIN - opponent_score)
+                    }
+                }
+
+                threats := teeko.PopCount(child_game.ThreatSquares(mover))
+                if sum_win_moves > best_sum || (sum_win_moves == best_sum && threats > best_threats) {
+                    best_sum = sum_win_moves
+                    best_threats = threats
+                    best_move_local = move_candidate
+                }
+            }
+            return playOrFallBack(record, game, best_move_local)
+        }
+    }
+    // Not TIE => use original BestDrop / BestMove
+    var best teeko.Bitboard
+    if game.Phase() == teeko.DropPhase {
+        best, _ = book.TryBestDrop(game)
+    } else {
+        best, _ = book.TryBestMove(game)
+    }
+    return playOrFallBack(record, game, best)
+}
+
+var errNoLegalAction = errors.New("no legal drop or move")
+
+// playOrFallBack plays action, or the first legal drop or move if the
+// search found nothing (action 0) or the record refuses it, so the
+// computer never passes its turn
+func playOrFallBack(record *teeko.Game, game teeko.Teeko, action teeko.Bitboard) error {
+    if action != 0 && record.Play(game.ToMove(action)) == nil {
+        return nil
+    }
+    var list teeko.ActionList
+    if game.Phase() == teeko.DropPhase {
+        game.GenerateDrops(&list)
+    } else {
+        game.GenerateMoves(&list)
+    }
+    err := errNoLegalAction
+    for _, fallback := range list.Slice() {
+        if err = record.Play(game.ToMove(fallback)); err == nil {
+            return nil
+        }
+    }
+    return err
+}
+
+
+// takeBack undoes or redoes plies moves so the same player is to move again
+func takeBack(record *teeko.Game, key int, plies int) {
+	for i := 0; i < plies; i++ {
+		if key == KeyUndo {
+			record.Undo()
+		} else {
+			record.Redo()
+		}
+	}
+}
+
+// playerMove lets a human play one move; u/r take back or replay plies moves instead
+func playerMove(book *solver.Book, record *teeko.Game, plies int) {
+	game := record.Current()
+	printBoardWithInfo(book, game, 0)
+	board := game.Rules.Board
+	
+
+	if game.Phase() == teeko.DropPhase {
+		// ---------------- DROP PHASE ----------------
+		cursor := board.Center()
+		for {
+			key := navigateBoard(board, &cursor)
+			if key == KeyUndo || key == KeyRedo {
+				takeBack(record, key, plies)
+				return
+			}
+			if key == KeyEnter {
+				if record.Play(teeko.MakeDrop(cursor)) == nil {
+					break
+				}
+			}
+		}
+
+	} else {
+		// --------------- MOVE PHASE -----------------
+		// 1) Select marker
+		cursor := board.Center()
+		var marker teeko.Square
+
+		for {
+			key := navigateBoard(board, &cursor)
+			if key == KeyUndo || key == KeyRedo {
+				takeBack(record, key, plies)
+				return
+			}
+			
+			if key == KeyEnter {
+				mask := board.SquareBit(cursor)
+				cpPositions := game.PositionsOf(game.CurrentPlayer)
+				if (cpPositions & mask) != 0 {
+					// Valid marker => store coords
+					marker = cursor
+
+					// === NEW PART: Re-print board (highlight the marker),
+					// then move cursor back to that same marker. ===
+					// 1) Re-print:
+					fmt.Print("\033[H\033[2J")
+					highlightMask := board.SquareBit(marker)
+					printBoardWithInfo(book, game, highlightMask)
+
+					// Print a quick line about next step
+
+					// 2) The default printing logic tries to place the cursor near center again.
+					//    So move from the center => marker.
+					//    We just do that directly:
+					moveCursorFromCenterTo(board, marker)
+
+					break
+				}
+			}
+		}
+
+		// 2) Select destination from the same screen
+		cursor = marker
+
+		for {
+			key := navigateBoard(board, &cursor)
+			if key == KeyUndo || key == KeyRedo {
+				takeBack(record, key, plies)
+				return
+			}
+			if key == KeyEnter {
+				if record.Play(teeko.MakeMove(marker, cursor)) == nil {
+					break
+				}
+				// else do nothing
+			}
+		}
+	}
+}
+
+// -------------------------------------------------------------------
+// main
+// -------------------------------------------------------------------
+func main() {
+    // the rules and key space must match the ones the book was solved with
+    mode_name := flag.String("mode", "advanced", "game mode: regular or advanced")
+    width := flag.Int("width", 5, "board columns")
+    height := flag.Int("height", 5, "board rows")
+    markers := flag.Int("markers", 4, "markers per side")
+    key_space := flag.String("keys", "full", "key space: full or symmetric")
+    book_path := flag.String("book", "book.txt", "book file to load")
+    flag.Parse()
+
+    game_mode, err := teeko.ParseGameMode(*mode_name)
+    if err != nil {
+        fmt.Println("Error reading the game mode:", err)
+        os.Exit(1)
+    }
+    board, err := teeko.MakeBoard(*width, *height)
+    if err != nil {
+        fmt.Println("Error building the board:", err)
+        os.Exit(1)
+    }
+    rules, err := teeko.MakeRules(game_mode, board, *markers)
+    if err != nil {
+        fmt.Println("Error building the rules:", err)
+        os.Exit(1)
+    }
+    keys, err := encoding.MakeKeySpace(*key_space, rules)
+    if err != nil {
+        fmt.Println("Error building the keys:", err)
+        os.Exit(1)
+    }
+    book, err := solver.LoadTable(*book_path, rules, keys)
+    if err != nil {
+        fmt.Println("Error loading book:", err)
+        os.Exit(1)
+    }
+
+    // 1) Clear screen at start
+    fmt.Print("\033[H\033[2J\u001b[0m")
+
+    // 2) Open keyboard for arrow key usage
+    if err := keyboard.Open(); err != nil {
+        fmt.Println("Cannot open keyboard:", err)
+        os.Exit(1)
+    }
+    defer keyboard.Close()
+
+    // 3) Print the menu exactly once
+    fmt.Print("Select Game Mode (use arrow keys, ENTER to confirm):\n")
+    fmt.Print("  - Player vs Player\n")
+    fmt.Print("  - Player vs Computer")
+
+    /*
+       After printing, the cursor is now at the end of line 4 (the " - Player vs AI").
+       We want our ">" cursor to start on line 3, left column 0, meaning next to " - Player vs Player".
+       We'll track lines like this:
+           lineIndex = 0 => row 3 (the "  - Player vs Player")
+           lineIndex = 1 => row 4 (the "  - Player vs AI")
+    */
+
+    // Move the cursor up from line 4 to line 3
+    fmt.Print("\x1b[A") // Move up 1 line (from row 4 => row 3)
+    // Move cursor all the way to column 0
+    fmt.Print("\r")
+
+    // Print ">" at the start of line 3
+    fmt.Print(">")
+
+    // We'll keep track of the current line index = 0 => "Player vs Player", 1 => "Player vs AI"
+    current_line := 0
+
+MenuLoop:
+    for {
+        // Read a key
+        _, key, err := keyboard.GetKey()
+        if err != nil {
+            fmt.Println("Error reading key:", err)
+            break
+        }
+
+        switch key {
+        case keyboard.KeyArrowUp:
+            // If we're not already at lineIndex=0, move cursor up
+            if current_line > 0 {
+                // Remove ">" from old line by overwriting with a space
+                fmt.Print("\r")      // move to start of the current line
+                fmt.Print(" ")       // overwrite ">"
+                // Move up one line
+                fmt.Print("\x1b[A")
+                // Move to col 0 again
+                fmt.Print("\r")
+                // Print ">"
+                fmt.Print(">")
+                current_line--
+            }
+
+        case keyboard.KeyArrowDown:
+            // If we're not already at lineIndex=1, move cursor down
+            if current_line < 1 {
+                // Remove ">" from old line
+                fmt.Print("\r")
+                fmt.Print(" ")
+                // Move down one line
+                fmt.Print("\x1b[B")
+                // Move to col 0
+                fmt.Print("\r")
+                // Print ">"
+                fmt.Print(">")
+                current_line++
+            }
+
+        case keyboard.KeyEnter:
+            // Confirm selection
+            break MenuLoop
+
+        default:
+            // Ignore other keys
+        }
+    }
+
+    // Clear the screen after menu
+    fmt.Print("\033[H\033[2J\u001b[0m")
+
+    // Decide mode based on lineIndex: 0 => PvP, 1 => PvAI
+    mode := current_line
+
+    // 4) Load Teeko table, create the game
+    record := teeko.MakeGame(rules, teeko.DrawRules{Repetitions: 3, MaxPlies: 0})
+
+    // 5) Main game loop
+    for record.Status().Result == teeko.Undecided {
+        game := record.Current()
+        if mode == 0 {
+            // Player vs Player => takebacks undo one ply
+            playerMove(&book, &record, 1)
+        } else {
+            // Player vs AI => takebacks undo the computer's reply too
+            if game.CurrentPlayer == teeko.BlackToMove {
+                playerMove(&book, &record, 2)
+            } else {
+                if err := computerMove(&book, &record); err != nil {
+                    fmt.Println("The computer cannot move:", err)
+                    return
+                }
+            }
+        }
+    }
+    game := record.Current()
+    status := record.Status()
+
+    // 6) Game is finished => print final board & winner (or why it is drawn)
+    fmt.Print("\033[H\033[2J\u001b[0m")
+    // highlight the winning shape (empty for a draw)
+    printTeeko(game, status.Pattern.Squares)
+    if status.Result == teeko.Drawn {
+        fmt.Printf("Game Over! Draw by %s.\n", status.Reason)
+    } else {
+        fmt.Printf("Game Over! Winner is: %s with a %s (%v)\n", func() string {
+            if status.Result == teeko.RedWins {
+                return "\u001b[31;1mRed\u001b[0m"
+            }
+            return "\u001b[30;1mBlack\u001b[0m"
+        }(), status.Pattern, status.Pattern.SquareList(game.Rules.Board))
+    }
+
+    // Final reset
+    fmt.Print("\u001b[0m")
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+    "fmt"
+)
+func printProgress(current, total int, changes uint) {
+	const PBWIDTH = 50
+    // Fraction done
+    fraction := float64(current) / float64(total)
+    // Number of '=' to display
+    filled := int(fraction * PBWIDTH)
+    // Print the bar
+    fmt.Printf("\r[")
+    for i := 0; i < filled; i++ {
+        fmt.Print("=")
+    }
+    for i := filled; i < PBWIDTH; i++ {
+        fmt.Print(" ")
+    }
+    fmt.Printf("] %.2f%% (%d/%d) Changes: %d", fraction*100.0, current, total, changes)
+}
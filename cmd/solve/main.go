@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/JackRubiralta/Go-Teeko/encoding"
+	"github.com/JackRubiralta/Go-Teeko/solver"
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// Solves the rules picked by the flags (standard Advanced Teeko by default)
+// and writes the book the play binary loads
+func main() {
+	mode_name := flag.String("mode", "advanced", "game mode: regular or advanced")
+	width := flag.Int("width", 5, "board columns")
+	height := flag.Int("height", 5, "board rows")
+	markers := flag.Int("markers", 4, "markers per side")
+	key_space := flag.String("keys", "full", "key space: full or symmetric")
+	book_path := flag.String("book", "book.txt", "book file to write")
+	flag.Parse()
+
+	mode, err := teeko.ParseGameMode(*mode_name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	board, err := teeko.MakeBoard(*width, *height)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rules, err := teeko.MakeRules(mode, board, *markers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keys, err := encoding.MakeKeySpace(*key_space, rules)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Solver Running! (%s, %s keys)\n", rules, keys.Name())
+	book := solver.Solve(rules, keys, func(key, max_key int, changes uint) {
+		printProgress(key, max_key, changes)
+		if key == max_key {
+			fmt.Println("")
+		}
+	})
+	if err := book.UploadTable(*book_path); err != nil {
+		log.Fatal(err)
+	}
+}
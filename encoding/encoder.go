@@ -0,0 +1,344 @@
+package encoding
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// rankCombination is the lexicographic rank of subset among all subsets of
+// the same size of n squares. Squares in skip are left out of the count, so
+// the other squares are renumbered 0..n-1 in bit order. The skipped ranges
+// are summed with the hockey-stick identity, one table lookup per marker.
+func rankCombination(subset, skip teeko.Bitboard, n int) int {
+	rank := 0
+	k := bits.OnesCount64(uint64(subset))
+	previous := -1
+	for subset != 0 {
+		square := bits.TrailingZeros64(uint64(subset))
+		subset &= subset - 1
+		// position among the squares that are not skipped
+		x := square - bits.OnesCount64(uint64(skip&(teeko.Bitboard(1)<<square-1)))
+		// subsets that pick previous+1 .. x-1 here come first
+		rank += binomial[n-previous-1][k] - binomial[n-x][k]
+		previous = x
+		k--
+	}
+	return rank
+}
+
+// unrankCombination is the inverse of rankCombination: the k squares, none
+// of them in skip, with this rank
+func unrankCombination(rank, k, n int, skip teeko.Bitboard) teeko.Bitboard {
+	var subset teeko.Bitboard
+	x := 0
+	for square := 0; k > 0; square++ {
+		if (skip>>square)&1 != 0 {
+			continue
+		}
+		c := binomial[n-1-x][k-1]
+		if rank < c {
+			subset |= teeko.Bitboard(1) << square
+			k--
+		} else {
+			rank -= c
+		}
+		x++
+	}
+	return subset
+}
+
+// ------------------------------------------------------------------- //
+// Reasons the Try variants of rank / unrank, encode / decode reject their input
+var (
+	ErrKeyOutOfRange = errors.New("key is outside the key space")
+	ErrNoLayer       = errors.New("no layer for these marker counts")
+	ErrWrongRules    = errors.New("position is played under other rules")
+	ErrTooManyKeys   = errors.New("key space does not fit in an int")
+	ErrNoKeySpace    = errors.New("no key space by this name")
+)
+
+// ------------------------------------------------------------------- //
+// Encoder numbers every placement of the side to move's markers (player)
+// and the other side's markers (opponent) on a board of size squares.
+//
+// Keys come in layers, one per pair of piece counts: rankCounts gives the
+// first key of a layer and rankPositions the place of the squares inside
+// it. Layers are ordered by total markers, then opponent count, and only
+// exist for the counts a game can reach: the opponent has as many markers
+// as the player or one more, and neither has more than markers.
+type Encoder struct {
+	size    int
+	markers int
+	layers  []Counts // piece counts of every layer, in key order
+	offsets []int    // first key of every layer, then MaxKey
+}
+
+// Counts is the number of markers of each side in a layer
+type Counts struct {
+	Opponent int
+	Player   int
+}
+
+// MakeEncoder refuses boards the markers do not fit on and key spaces with
+// more keys than an int can count
+func MakeEncoder(size, markers int) (*Encoder, error) {
+	if size > teeko.BITBOARD_BITS || markers < 0 || 2*markers > size {
+		return nil, fmt.Errorf("%w: %d on %d squares", teeko.ErrBadMarkers, markers, size)
+	}
+
+	var encoder Encoder
+	encoder.size = size
+	encoder.markers = markers
+	accum := 0
+	for total := 0; total <= 2*markers; total++ {
+		for o := 0; o <= markers; o++ {
+			counts := Counts{o, total - o}
+			if !encoder.isLayer(counts) {
+				continue
+			}
+			encoder.layers = append(encoder.layers, counts)
+			encoder.offsets = append(encoder.offsets, accum)
+			// refuse key spaces whose keys would wrap around
+			high, layer_size := bits.Mul64(uint64(binomial[size][o]), uint64(binomial[size-o][total-o]))
+			if high != 0 || layer_size > uint64(math.MaxInt-accum) {
+				return nil, fmt.Errorf("%w: %d markers per side on %d squares", ErrTooManyKeys, markers, size)
+			}
+			accum += int(layer_size)
+		}
+	}
+	encoder.offsets = append(encoder.offsets, accum)
+	return &encoder, nil
+}
+
+// isLayer reports whether the encoder has keys for counts
+func (encoder *Encoder) isLayer(counts Counts) bool {
+	o, p := counts.Opponent, counts.Player
+	return p >= 0 && o <= encoder.markers && (o == p || o == p+1)
+}
+
+// MaxKey is the number of keys; they run from 0 to MaxKey()-1
+func (encoder *Encoder) MaxKey() int {
+	return encoder.offsets[len(encoder.layers)]
+}
+
+// LayerSize is the number of keys with these piece counts, 0 if there is no such layer
+func (encoder *Encoder) LayerSize(counts Counts) int {
+	if !encoder.isLayer(counts) {
+		return 0
+	}
+	return binomial[encoder.size][counts.Opponent] * binomial[encoder.size-counts.Opponent][counts.Player]
+}
+
+// RankCounts returns the first key of the layer with these piece counts
+func (encoder *Encoder) RankCounts(counts Counts) int {
+	if !encoder.isLayer(counts) {
+		log.Fatalf("Encoder.RankCounts: no layer for %d opponent and %d player markers", counts.Opponent, counts.Player)
+	}
+	// every total has exactly one layer (the opponent holds the odd
+	// marker), so the layer number is the total
+	total := counts.Opponent + counts.Player
+	return encoder.offsets[total]
+}
+
+// UnrankCounts returns the piece counts of key's layer and key's rank inside it
+func (encoder *Encoder) UnrankCounts(key int) (Counts, int) {
+	if key < 0 || key >= encoder.MaxKey() {
+		log.Fatalf("Encoder.UnrankCounts: key=%d out of range (MAX_KEY=%d)", key, encoder.MaxKey())
+	}
+	// last layer whose first key is <= key
+	layer := sort.Search(len(encoder.layers), func(i int) bool {
+		return encoder.offsets[i] > key
+	}) - 1
+	return encoder.layers[layer], key - encoder.offsets[layer]
+}
+
+// RankPositions is the rank of a placement inside its layer. The opponent's
+// squares are ranked among all squares, the player's among the squares the
+// opponent leaves free.
+func (encoder *Encoder) RankPositions(opponent, player teeko.Bitboard) int {
+	opponent_count := teeko.PopCount(opponent)
+	player_count := teeko.PopCount(player)
+
+	opponent_rank := rankCombination(opponent, 0, encoder.size)
+	player_rank := rankCombination(player, opponent, encoder.size-opponent_count)
+	ways_for_player := binomial[encoder.size-opponent_count][player_count]
+	return opponent_rank*ways_for_player + player_rank
+}
+
+// UnrankPositions is the placement with this rank in the layer with counts
+func (encoder *Encoder) UnrankPositions(counts Counts, rank int) (opponent, player teeko.Bitboard) {
+	ways_for_player := binomial[encoder.size-counts.Opponent][counts.Player]
+	opponent = unrankCombination(rank/ways_for_player, counts.Opponent, encoder.size, 0)
+	player = unrankCombination(rank%ways_for_player, counts.Player, encoder.size-counts.Opponent, opponent)
+	return opponent, player
+}
+
+// Rank returns the key of a placement
+func (encoder *Encoder) Rank(opponent, player teeko.Bitboard) int {
+	counts := Counts{teeko.PopCount(opponent), teeko.PopCount(player)}
+	return encoder.RankCounts(counts) + encoder.RankPositions(opponent, player)
+}
+
+// Unrank returns the placement with this key
+func (encoder *Encoder) Unrank(key int) (opponent, player teeko.Bitboard) {
+	counts, rank := encoder.UnrankCounts(key)
+	return encoder.UnrankPositions(counts, rank)
+}
+
+// TryRank is Rank for untrusted input: it rejects markers off the board,
+// squares held by both sides and piece counts without a layer
+func (encoder *Encoder) TryRank(opponent, player teeko.Bitboard) (int, error) {
+	if ((opponent | player) >> encoder.size) != 0 {
+		return 0, teeko.ErrMarkersOffBoard
+	}
+	if (opponent & player) != 0 {
+		return 0, teeko.ErrSharedSquares
+	}
+	if !encoder.isLayer(Counts{teeko.PopCount(opponent), teeko.PopCount(player)}) {
+		return 0, ErrNoLayer
+	}
+	return encoder.Rank(opponent, player), nil
+}
+
+// TryUnrank is Unrank for untrusted keys
+func (encoder *Encoder) TryUnrank(key int) (opponent, player teeko.Bitboard, err error) {
+	if key < 0 || key >= encoder.MaxKey() {
+		return 0, 0, ErrKeyOutOfRange
+	}
+	opponent, player = encoder.Unrank(key)
+	return opponent, player, nil
+}
+
+// ------------------------------------------------------------------- //
+// FullKeys gives every position under one rule set its own key,
+// the Encoder key of its markers.
+type FullKeys struct {
+	rules   *teeko.Rules
+	encoder *Encoder
+}
+
+func MakeFullKeys(rules *teeko.Rules) (*FullKeys, error) {
+	encoder, err := MakeEncoder(rules.Board.Size, rules.Markers)
+	if err != nil {
+		return nil, err
+	}
+	return &FullKeys{rules, encoder}, nil
+}
+
+// Encode ranks the opponent's markers and the side to move's markers,
+// see Teeko.RelativePositions
+func (keys *FullKeys) Encode(game teeko.Teeko) int {
+	player, opponent := game.RelativePositions()
+	return keys.encoder.Rank(opponent, player)
+}
+
+// Decode: from an integer key -> a new Teeko struct played under keys.rules.
+// We'll interpret the "player" bits vs "opponent" bits, then
+// set game.CurrentPlayer = BlackToMove if total # markers is even, else RedToMove.
+// Once every marker is dropped the key has no side to move; see DecodeAs.
+func (keys *FullKeys) Decode(key int) teeko.Teeko {
+	counts, rank := keys.encoder.UnrankCounts(key)
+	opponent_mask, player_positions := keys.encoder.UnrankPositions(counts, rank)
+	return keys.position(counts, opponent_mask, player_positions)
+}
+
+// position builds the Teeko value of a placement with counts
+func (keys *FullKeys) position(counts Counts, opponent_mask, player_positions teeko.Bitboard) teeko.Teeko {
+	rules := keys.rules
+	var current_player teeko.Player
+	if counts.Opponent+counts.Player < 2*rules.Markers {
+		// drop phase
+		if counts.Opponent == counts.Player {
+			current_player = teeko.BlackToMove
+		} else {
+			current_player = teeko.RedToMove
+		}
+	} else {
+		// move phase
+		current_player = teeko.BlackToMove
+	}
+
+	return teeko.MakeRelativePosition(rules, player_positions, opponent_mask, current_player)
+}
+
+// TryEncode is Encode for untrusted positions. Legality is not checked
+// (books hold illegal positions too), only that game has a key.
+func (keys *FullKeys) TryEncode(game teeko.Teeko) (int, error) {
+	if game.Rules == nil || (game.Rules != keys.rules && game.Rules.String() != keys.rules.String()) {
+		return 0, ErrWrongRules
+	}
+	player, opponent := game.RelativePositions()
+	return keys.encoder.TryRank(opponent, player)
+}
+
+// TryDecode is Decode for untrusted keys
+func (keys *FullKeys) TryDecode(key int) (teeko.Teeko, error) {
+	if key < 0 || key >= keys.MaxKey() {
+		return teeko.Teeko{}, ErrKeyOutOfRange
+	}
+	return keys.Decode(key), nil
+}
+
+// DecodeAs is Decode with the side to move given back. The key of a
+// move-phase position does not record whose turn it is (Decode answers
+// Black), so the same key decodes to either colour. A drop-phase key fixes
+// the side to move, and to_move must match it.
+func (keys *FullKeys) DecodeAs(key int, to_move teeko.Player) (teeko.Teeko, error) {
+	game, err := keys.TryDecode(key)
+	if err != nil {
+		return game, err
+	}
+	if game.CurrentPlayer == to_move {
+		return game, nil
+	}
+	player, opponent := game.RelativePositions()
+	if teeko.PopCount(player|opponent) < 2*keys.rules.Markers {
+		return game, teeko.ErrUnequalMarkers
+	}
+	// the markers stay with the side to move, only their colour changes
+	return teeko.MakeRelativePosition(keys.rules, player, opponent, to_move), nil
+}
+
+// ------------------------------------------------------------------- //
+// KeySpace maps positions to table keys for a Book. FullKeys gives every
+// position its own key; SymmetricKeys only ranks canonical positions.
+type KeySpace interface {
+	Encode(game teeko.Teeko) int
+	Decode(key int) teeko.Teeko
+	TryEncode(game teeko.Teeko) (int, error) // Encode without trusting game
+	TryDecode(key int) (teeko.Teeko, error)  // Decode without trusting key
+	DecodeAs(key int, to_move teeko.Player) (teeko.Teeko, error)
+	MaxKey() int
+	Name() string // recorded in the book header
+	Layers() []Layer
+	Iterate(layer Layer) *PositionIterator
+}
+
+// MakeKeySpace builds the key space with this Name for rules, e.g. from a
+// command-line flag
+func MakeKeySpace(name string, rules *teeko.Rules) (KeySpace, error) {
+	switch name {
+	case "full":
+		keys, err := MakeFullKeys(rules)
+		if err != nil {
+			return nil, err
+		}
+		return keys, nil
+	case "symmetric":
+		keys, err := MakeSymmetricKeys(rules)
+		if err != nil {
+			return nil, err
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrNoKeySpace, name)
+}
+
+func (keys *FullKeys) MaxKey() int  { return keys.encoder.MaxKey() }
+func (keys *FullKeys) Name() string { return "full" }
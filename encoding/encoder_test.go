@@ -0,0 +1,307 @@
+package encoding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// makeRules builds Advanced rules on a width x height board, failing tb if
+// they are refused
+func makeRules(tb testing.TB, width, height, markers int) *teeko.Rules {
+	tb.Helper()
+	board, err := teeko.MakeBoard(width, height)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	rules, err := teeko.MakeRules(teeko.Advanced, board, markers)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return rules
+}
+
+// makeFullKeys builds the full key space of rules, failing tb if it is refused
+func makeFullKeys(tb testing.TB, rules *teeko.Rules) *FullKeys {
+	tb.Helper()
+	keys, err := MakeFullKeys(rules)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return keys
+}
+
+// Keys of the standard 5x5 Advanced game, as written by the original
+// encoder; every book on disk depends on them
+var pinned_keys = []struct {
+	player, occupied teeko.Bitboard
+	key              int
+}{
+	{0, 0, 0},
+	{4, 524292, 484},
+	{20971520, 21495812, 23716},
+	{6307840, 6570080, 2419516},
+	{4195464, 4200329, 29039516},
+	{1050896, 9964312, 58079516},
+	{525392, 8995952, 72599516},
+	{598017, 10428417, 96315516},
+}
+
+func TestPinnedKeys(t *testing.T) {
+	keys := makeFullKeys(t, makeRules(t, 5, 5, 4))
+	if keys.MaxKey() != 96691476 {
+		t.Fatalf("MaxKey() = %d, want 96691476", keys.MaxKey())
+	}
+	for _, pinned := range pinned_keys {
+		game := keys.Decode(pinned.key)
+		player, opponent := game.RelativePositions()
+		if player != pinned.player || player|opponent != pinned.occupied {
+			t.Errorf("Decode(%d) = %b / %b, want %b / %b", pinned.key, player, player|opponent, pinned.player, pinned.occupied)
+		}
+		if key := keys.Encode(game); key != pinned.key {
+			t.Errorf("Encode gives %d, want %d", key, pinned.key)
+		}
+	}
+}
+
+// referenceKey is the original slice-based encoder, kept to check the
+// table-driven one against
+func referenceKey(size, markers int, player, opponent teeko.Bitboard) int {
+	choose := func(n, k int) int {
+		if k < 0 || k > n {
+			return 0
+		}
+		result := 1
+		for i := 0; i < k; i++ {
+			result = result * (n - i) / (i + 1)
+		}
+		return result
+	}
+	rank := func(subset []int, n int) int {
+		rank, previous := 0, -1
+		for i, x := range subset {
+			for v := previous + 1; v < x; v++ {
+				rank += choose(n-1-v, len(subset)-1-i)
+			}
+			previous = x
+		}
+		return rank
+	}
+
+	player_count, opponent_count := teeko.PopCount(player), teeko.PopCount(opponent)
+	base := 0
+	for total := 0; total <= 2*markers; total++ {
+		for o := 0; o <= markers; o++ {
+			p := total - o
+			if p < 0 || p > markers || !(o == p || o == p+1) {
+				continue
+			}
+			if o == opponent_count && p == player_count {
+				goto found
+			}
+			base += choose(size, o) * choose(size-o, p)
+		}
+	}
+found:
+	var leftover, relative []int
+	for square := 0; square < size; square++ {
+		if (opponent>>square)&1 == 0 {
+			if (player>>square)&1 != 0 {
+				relative = append(relative, len(leftover))
+			}
+			leftover = append(leftover, square)
+		}
+	}
+	opponent_rank := rank(teeko.BitboardToArray(opponent), size)
+	player_rank := rank(relative, size-opponent_count)
+	return base + opponent_rank*choose(size-opponent_count, player_count) + player_rank
+}
+
+func TestKeysMatchReferenceEncoder(t *testing.T) {
+	for _, shape := range [][3]int{{4, 4, 3}, {4, 6, 3}, {5, 5, 4}} {
+		rules := makeRules(t, shape[0], shape[1], shape[2])
+		keys := makeFullKeys(t, rules)
+		step := keys.MaxKey()/100000 + 1
+		for key := 0; key < keys.MaxKey(); key += step {
+			game := keys.Decode(key)
+			player, opponent := game.RelativePositions()
+			if reference := referenceKey(rules.Board.Size, rules.Markers, player, opponent); reference != key {
+				t.Fatalf("%v: key %d was %d under the original encoder", shape, key, reference)
+			}
+		}
+	}
+}
+
+func TestEncodeDecodeDoNotAllocate(t *testing.T) {
+	keys := makeFullKeys(t, makeRules(t, 5, 5, 4))
+	game := keys.Decode(pinned_keys[len(pinned_keys)-1].key)
+	if allocs := testing.AllocsPerRun(100, func() { keys.Decode(keys.Encode(game)) }); allocs != 0 {
+		t.Errorf("Encode + Decode allocate %v times", allocs)
+	}
+}
+
+func TestMakeEncoderRefusesOverflow(t *testing.T) {
+	if _, err := MakeEncoder(64, 16); !errors.Is(err, ErrTooManyKeys) {
+		t.Errorf("MakeEncoder(64, 16) error = %v, want ErrTooManyKeys", err)
+	}
+	if _, err := MakeEncoder(10, 6); !errors.Is(err, teeko.ErrBadMarkers) {
+		t.Errorf("MakeEncoder(10, 6) error = %v, want ErrBadMarkers", err)
+	}
+	// the largest layer of 64 squares with 8 markers each still fits
+	encoder, err := MakeEncoder(64, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoder.MaxKey() <= 0 {
+		t.Errorf("MaxKey() = %d", encoder.MaxKey())
+	}
+}
+
+func TestMakeKeySpaceByName(t *testing.T) {
+	rules := makeRules(t, 4, 4, 3)
+	for _, name := range []string{"full", "symmetric"} {
+		keys, err := MakeKeySpace(name, rules)
+		if err != nil || keys.Name() != name {
+			t.Errorf("MakeKeySpace(%q) = %v, %v", name, keys, err)
+		}
+	}
+	if _, err := MakeKeySpace("compressed", rules); !errors.Is(err, ErrNoKeySpace) {
+		t.Errorf("MakeKeySpace of an unknown name: %v, want ErrNoKeySpace", err)
+	}
+}
+
+func TestTryVariantsRoundTrip(t *testing.T) {
+	rules := makeRules(t, 4, 4, 3)
+	keys := makeFullKeys(t, rules)
+	symmetric, err := MakeSymmetricKeys(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key := 0; key < keys.MaxKey(); key++ {
+		opponent, player, err := keys.encoder.TryUnrank(key)
+		if err != nil {
+			t.Fatalf("TryUnrank(%d): %v", key, err)
+		}
+		if ranked, err := keys.encoder.TryRank(opponent, player); err != nil || ranked != key {
+			t.Fatalf("TryRank of key %d = %d, %v", key, ranked, err)
+		}
+		game, err := keys.TryDecode(key)
+		if err != nil {
+			t.Fatalf("TryDecode(%d): %v", key, err)
+		}
+		if encoded, err := keys.TryEncode(game); err != nil || encoded != key {
+			t.Fatalf("TryEncode of key %d = %d, %v", key, encoded, err)
+		}
+		if encoded, err := symmetric.TryEncode(game); err != nil || encoded != symmetric.Encode(game) {
+			t.Fatalf("symmetric TryEncode of key %d = %d, %v", key, encoded, err)
+		}
+	}
+	for key := 0; key < symmetric.MaxKey(); key++ {
+		game, err := symmetric.TryDecode(key)
+		if err != nil || symmetric.Encode(game) != key {
+			t.Fatalf("symmetric TryDecode(%d) does not round-trip (%v)", key, err)
+		}
+	}
+}
+
+func TestTryVariantsReject(t *testing.T) {
+	rules := makeRules(t, 4, 4, 3)
+	keys := makeFullKeys(t, rules)
+	symmetric, err := MakeSymmetricKeys(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other_rules := makeRules(t, 5, 5, 3)
+
+	for _, key := range []int{-1, keys.MaxKey()} {
+		if _, _, err := keys.encoder.TryUnrank(key); err != ErrKeyOutOfRange {
+			t.Errorf("TryUnrank(%d): %v, want ErrKeyOutOfRange", key, err)
+		}
+		if _, err := keys.TryDecode(key); err != ErrKeyOutOfRange {
+			t.Errorf("TryDecode(%d): %v, want ErrKeyOutOfRange", key, err)
+		}
+		if _, err := keys.DecodeAs(key, teeko.BlackToMove); err != ErrKeyOutOfRange {
+			t.Errorf("DecodeAs(%d): %v, want ErrKeyOutOfRange", key, err)
+		}
+	}
+	for _, key := range []int{-1, symmetric.MaxKey()} {
+		if _, err := symmetric.TryDecode(key); err != ErrKeyOutOfRange {
+			t.Errorf("symmetric TryDecode(%d): %v, want ErrKeyOutOfRange", key, err)
+		}
+		if _, err := symmetric.DecodeAs(key, teeko.BlackToMove); err != ErrKeyOutOfRange {
+			t.Errorf("symmetric DecodeAs(%d): %v, want ErrKeyOutOfRange", key, err)
+		}
+	}
+
+	for _, test := range []struct {
+		name             string
+		opponent, player teeko.Bitboard
+		want             error
+	}{
+		{"off the board", 1 << 20, 0, teeko.ErrMarkersOffBoard},
+		{"shared square", 0b1, 0b1, teeko.ErrSharedSquares},
+		{"player ahead", 0, 0b11, ErrNoLayer},
+		{"opponent two ahead", 0b11, 0, ErrNoLayer},
+		{"too many markers", 0b1111, 0b11110000, ErrNoLayer},
+	} {
+		if _, err := keys.encoder.TryRank(test.opponent, test.player); err != test.want {
+			t.Errorf("TryRank %s: %v, want %v", test.name, err, test.want)
+		}
+	}
+
+	for name, game := range map[string]teeko.Teeko{
+		"no rules":    {},
+		"other board": teeko.MakeTeeko(other_rules),
+	} {
+		if _, err := keys.TryEncode(game); err != ErrWrongRules {
+			t.Errorf("TryEncode with %s: %v, want ErrWrongRules", name, err)
+		}
+		if _, err := symmetric.TryEncode(game); err != ErrWrongRules {
+			t.Errorf("symmetric TryEncode with %s: %v, want ErrWrongRules", name, err)
+		}
+	}
+	no_layer := teeko.MakeRelativePosition(rules, 0b11, 0, teeko.BlackToMove)
+	if _, err := keys.TryEncode(no_layer); err != ErrNoLayer {
+		t.Errorf("TryEncode with impossible counts: %v, want ErrNoLayer", err)
+	}
+}
+
+func TestDecodeAs(t *testing.T) {
+	rules := makeRules(t, 4, 4, 3)
+	keys := makeFullKeys(t, rules)
+	symmetric, err := MakeSymmetricKeys(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, space := range []KeySpace{keys, symmetric} {
+		// after Black's first drop only Red can be to move
+		first := teeko.MakeTeeko(rules)
+		first.DropMarker(1)
+		drop_key := space.Encode(first)
+		if game, err := space.DecodeAs(drop_key, teeko.RedToMove); err != nil || game != space.Decode(drop_key) {
+			t.Errorf("%s: DecodeAs(drop key, Red) = %v", space.Name(), err)
+		}
+		if _, err := space.DecodeAs(drop_key, teeko.BlackToMove); err != teeko.ErrUnequalMarkers {
+			t.Errorf("%s: DecodeAs(drop key, Black): %v, want ErrUnequalMarkers", space.Name(), err)
+		}
+
+		// a move-phase key decodes to either colour with the same markers to move
+		move_key := space.MaxKey() - 1
+		black := space.Decode(move_key)
+		if black.Phase() != teeko.MovePhase || black.CurrentPlayer != teeko.BlackToMove {
+			t.Fatalf("%s: key %d is not a move-phase key with Black to move", space.Name(), move_key)
+		}
+		red, err := space.DecodeAs(move_key, teeko.RedToMove)
+		if err != nil {
+			t.Fatalf("%s: DecodeAs(move key, Red): %v", space.Name(), err)
+		}
+		if red.CurrentPlayer != teeko.RedToMove || red.Red() != black.Black() || red.Black() != black.Red() {
+			t.Errorf("%s: DecodeAs(move key, Red) changed the markers", space.Name())
+		}
+		if key, err := space.TryEncode(red); err != nil || key != move_key {
+			t.Errorf("%s: DecodeAs(move key, Red) encodes to %d, %v", space.Name(), key, err)
+		}
+	}
+}
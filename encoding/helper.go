@@ -0,0 +1,20 @@
+package encoding
+
+import (
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// binomial[n][k] is n choose k, built once from Pascal's triangle so the
+// encoder never multiplies or divides
+var binomial = makeBinomials()
+
+func makeBinomials() [teeko.BITBOARD_BITS + 1][teeko.BITBOARD_BITS + 1]int {
+	var table [teeko.BITBOARD_BITS + 1][teeko.BITBOARD_BITS + 1]int
+	for n := 0; n <= teeko.BITBOARD_BITS; n++ {
+		table[n][0] = 1
+		for k := 1; k <= n; k++ {
+			table[n][k] = table[n-1][k-1] + table[n-1][k]
+		}
+	}
+	return table
+}
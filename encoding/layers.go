@@ -0,0 +1,180 @@
+package encoding
+
+import (
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// Layer is the block of consecutive keys of one pair of piece counts
+type Layer struct {
+	Counts
+	First int // first key of the layer
+	Size  int // number of keys, see Encoder.LayerSize
+}
+
+// Layers lists the layers in key order; together they cover 0..MaxKey()-1
+func (encoder *Encoder) Layers() []Layer {
+	layers := make([]Layer, len(encoder.layers))
+	for i, counts := range encoder.layers {
+		layers[i] = Layer{counts, encoder.offsets[i], encoder.offsets[i+1] - encoder.offsets[i]}
+	}
+	return layers
+}
+
+// LayerIterator walks the placements of one layer in key order. It steps
+// from one combination of squares to the next instead of unranking every
+// key, so a whole layer costs about as much as reading it.
+//
+//	for it := encoder.Iterate(layer); it.Next(); {
+//		opponent, player := it.Positions()
+//	}
+type LayerIterator struct {
+	size    int
+	layer   Layer
+	key     int
+	started bool
+
+	opponent      [teeko.BITBOARD_BITS]int // opponent squares, ascending
+	free          [teeko.BITBOARD_BITS]int // squares the opponent leaves free, ascending
+	player        [teeko.BITBOARD_BITS]int // player squares as indices into free
+	opponent_mask teeko.Bitboard
+}
+
+// Iterate returns an iterator over layer; call Next before the first position
+func (encoder *Encoder) Iterate(layer Layer) *LayerIterator {
+	return &LayerIterator{size: encoder.size, layer: layer}
+}
+
+// Next steps to the next placement; false once the layer is done
+func (it *LayerIterator) Next() bool {
+	opponent := it.opponent[:it.layer.Opponent]
+	player := it.player[:it.layer.Player]
+	if !it.started {
+		it.started = true
+		it.key = it.layer.First
+		firstCombination(opponent)
+		it.updateFree()
+		firstCombination(player)
+		return it.layer.Size > 0
+	}
+
+	it.key++
+	if it.key >= it.layer.First+it.layer.Size {
+		return false
+	}
+	// the player's squares change fastest, as in RankPositions
+	if !nextCombination(player, it.size-it.layer.Opponent) {
+		nextCombination(opponent, it.size)
+		it.updateFree()
+		firstCombination(player)
+	}
+	return true
+}
+
+// Key returns the key of the current placement
+func (it *LayerIterator) Key() int {
+	return it.key
+}
+
+// Positions returns the current placement, as Unrank(Key()) would
+func (it *LayerIterator) Positions() (opponent, player teeko.Bitboard) {
+	for _, index := range it.player[:it.layer.Player] {
+		player |= teeko.Bitboard(1) << it.free[index]
+	}
+	return it.opponent_mask, player
+}
+
+// updateFree recomputes the opponent mask and free squares after the
+// opponent's squares changed
+func (it *LayerIterator) updateFree() {
+	it.opponent_mask = 0
+	for _, square := range it.opponent[:it.layer.Opponent] {
+		it.opponent_mask |= teeko.Bitboard(1) << square
+	}
+	free := 0
+	for square := 0; square < it.size; square++ {
+		if (it.opponent_mask>>square)&1 == 0 {
+			it.free[free] = square
+			free++
+		}
+	}
+}
+
+// firstCombination sets c to 0, 1, ..., the combination with rank 0
+func firstCombination(c []int) {
+	for i := range c {
+		c[i] = i
+	}
+}
+
+// nextCombination steps the ascending indices c to the next subset of
+// 0..n-1 in the order rankCombination ranks them; false after the last one
+func nextCombination(c []int, n int) bool {
+	k := len(c)
+	for i := k - 1; i >= 0; i-- {
+		if c[i] < n-k+i {
+			c[i]++
+			for j := i + 1; j < k; j++ {
+				c[j] = c[j-1] + 1
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// PositionIterator walks the positions of one layer of a KeySpace in key
+// order. For SymmetricKeys it steps through the full layer and skips the
+// positions that are not canonical.
+//
+//	for it := keys.Iterate(layer); it.Next(); {
+//		game := it.Position()
+//	}
+type PositionIterator struct {
+	*LayerIterator
+	keys      *FullKeys
+	symmetric *SymmetricKeys // nil when iterating FullKeys
+	key       int
+}
+
+// Layers lists the layers of the key space, see Encoder.Layers
+func (keys *FullKeys) Layers() []Layer {
+	return keys.encoder.Layers()
+}
+
+// Iterate returns an iterator over the positions of layer
+func (keys *FullKeys) Iterate(layer Layer) *PositionIterator {
+	return &PositionIterator{LayerIterator: keys.encoder.Iterate(layer), keys: keys}
+}
+
+// Next steps to the next position; false once the layer is done
+func (it *PositionIterator) Next() bool {
+	if it.symmetric == nil {
+		return it.LayerIterator.Next()
+	}
+	first := !it.started
+	for it.LayerIterator.Next() {
+		if it.symmetric.isCanonical(it.LayerIterator.Key()) {
+			if first {
+				it.key = it.symmetric.rank(it.LayerIterator.Key())
+			} else {
+				it.key++
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the key of the current position in the iterated key space
+func (it *PositionIterator) Key() int {
+	if it.symmetric == nil {
+		return it.LayerIterator.Key()
+	}
+	return it.key
+}
+
+// Position returns the current position, as Decode(Key()) would
+func (it *PositionIterator) Position() teeko.Teeko {
+	opponent, player := it.Positions()
+	return it.keys.position(it.layer.Counts, opponent, player)
+}
@@ -0,0 +1,58 @@
+package encoding
+
+import (
+	"testing"
+)
+
+// checkIteration walks every layer of keys and checks that the layers tile
+// 0..MaxKey()-1 and that each position is the one Decode gives its key
+func checkIteration(t *testing.T, keys KeySpace) {
+	t.Helper()
+	next := 0
+	for _, layer := range keys.Layers() {
+		if layer.First != next {
+			t.Fatalf("%s: layer %+v starts at %d, want %d", keys.Name(), layer.Counts, layer.First, next)
+		}
+		count := 0
+		for it := keys.Iterate(layer); it.Next(); count++ {
+			if it.Key() != layer.First+count {
+				t.Fatalf("%s: key %d, want %d", keys.Name(), it.Key(), layer.First+count)
+			}
+			if game := it.Position(); game != keys.Decode(it.Key()) {
+				t.Fatalf("%s: position of key %d differs from Decode", keys.Name(), it.Key())
+			}
+		}
+		if count != layer.Size {
+			t.Fatalf("%s: layer %+v yielded %d positions, want %d", keys.Name(), layer.Counts, count, layer.Size)
+		}
+		next += layer.Size
+	}
+	if next != keys.MaxKey() {
+		t.Fatalf("%s: layers cover %d keys, want %d", keys.Name(), next, keys.MaxKey())
+	}
+}
+
+func TestIterateMatchesDecode(t *testing.T) {
+	for _, shape := range [][3]int{{4, 4, 3}, {3, 5, 3}, {5, 5, 2}} {
+		rules := makeRules(t, shape[0], shape[1], shape[2])
+		checkIteration(t, makeFullKeys(t, rules))
+		symmetric, err := MakeSymmetricKeys(rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkIteration(t, symmetric)
+	}
+}
+
+func TestLayerIteratorMatchesUnrank(t *testing.T) {
+	encoder := makeFullKeys(t, makeRules(t, 4, 4, 3)).encoder
+	for _, layer := range encoder.Layers() {
+		for it := encoder.Iterate(layer); it.Next(); {
+			opponent, player := it.Positions()
+			want_opponent, want_player := encoder.Unrank(it.Key())
+			if opponent != want_opponent || player != want_player {
+				t.Fatalf("key %d: iterator gives %b / %b, Unrank %b / %b", it.Key(), opponent, player, want_opponent, want_player)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package encoding
+
+import (
+	"log"
+	"math/bits"
+	"sort"
+
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// SymmetricKeys ranks only canonical positions (see Teeko.Canonical), so a
+// book needs about an eighth of the keys on a square board.
+//
+// A position's key is the number of canonical positions whose full key
+// (FullKeys.Encode) is smaller. canonical marks the canonical full keys, one bit
+// each, and ranks holds the count before every word so encode is one
+// popcount and decode a binary search.
+//
+// The index costs about 2 bits per full key (1 for canonical, 64 per word
+// for ranks): 300 MB for the 1.2e9 full keys of the 5-marker 5x5 game. It is
+// not stored in the book, so MakeSymmetricKeys rebuilds it on every load by
+// decoding and canonicalizing every full key, which for that game is 1.2e9
+// decodes before the first lookup.
+type SymmetricKeys struct {
+	full      *FullKeys
+	canonical []uint64
+	ranks     []uint64
+	max_key   int
+}
+
+// MakeSymmetricKeys decodes every full key once to find the canonical ones;
+// see SymmetricKeys for what that costs
+func MakeSymmetricKeys(rules *teeko.Rules) (*SymmetricKeys, error) {
+	full, err := MakeFullKeys(rules)
+	if err != nil {
+		return nil, err
+	}
+	var keys SymmetricKeys
+	keys.full = full
+	words := (keys.full.MaxKey() + 63) / 64
+	keys.canonical = make([]uint64, words)
+	keys.ranks = make([]uint64, words)
+
+	for key := 0; key < keys.full.MaxKey(); key++ {
+		game := keys.full.Decode(key)
+		representative, _ := game.Canonical()
+		if representative == game {
+			keys.canonical[key/64] |= uint64(1) << (key % 64)
+		}
+	}
+
+	count := 0
+	for word := 0; word < words; word++ {
+		keys.ranks[word] = uint64(count)
+		count += bits.OnesCount64(keys.canonical[word])
+	}
+	keys.max_key = count
+	return &keys, nil
+}
+
+// Encode canonicalizes game and ranks it among the canonical positions
+func (keys *SymmetricKeys) Encode(game teeko.Teeko) int {
+	representative, _ := game.Canonical()
+	return keys.rank(keys.full.Encode(representative))
+}
+
+// rank counts the canonical full keys below full_key, which may be
+// full.MaxKey()
+func (keys *SymmetricKeys) rank(full_key int) int {
+	if full_key >= keys.full.MaxKey() {
+		return keys.max_key
+	}
+	word := full_key / 64
+	below := keys.canonical[word] & (uint64(1)<<(full_key%64) - 1)
+	return int(keys.ranks[word]) + bits.OnesCount64(below)
+}
+
+// isCanonical reports whether the position with this full key is canonical
+func (keys *SymmetricKeys) isCanonical(full_key int) bool {
+	return (keys.canonical[full_key/64]>>(full_key%64))&1 != 0
+}
+
+// Decode returns the canonical position with this key
+func (keys *SymmetricKeys) Decode(key int) teeko.Teeko {
+	if key < 0 || key >= keys.max_key {
+		log.Fatalf("SymmetricKeys.Decode: key=%d out of range (MAX_KEY=%d)", key, keys.max_key)
+	}
+	return keys.full.Decode(keys.fullKey(key))
+}
+
+// fullKey returns the FullKeys key of the canonical position with this key
+func (keys *SymmetricKeys) fullKey(key int) int {
+	// last word whose rank is <= key
+	word := sort.Search(len(keys.ranks), func(i int) bool {
+		return int(keys.ranks[i]) > key
+	}) - 1
+
+	// skip the canonical keys before ours inside the word
+	remaining := keys.canonical[word]
+	for skip := key - int(keys.ranks[word]); skip > 0; skip-- {
+		remaining &= remaining - 1
+	}
+	return word*64 + bits.TrailingZeros64(remaining)
+}
+
+// TryEncode is Encode for untrusted positions, see FullKeys.TryEncode
+func (keys *SymmetricKeys) TryEncode(game teeko.Teeko) (int, error) {
+	if _, err := keys.full.TryEncode(game); err != nil {
+		return 0, err
+	}
+	return keys.Encode(game), nil
+}
+
+// TryDecode is Decode for untrusted keys
+func (keys *SymmetricKeys) TryDecode(key int) (teeko.Teeko, error) {
+	if key < 0 || key >= keys.max_key {
+		return teeko.Teeko{}, ErrKeyOutOfRange
+	}
+	return keys.Decode(key), nil
+}
+
+// DecodeAs is Decode with the side to move given back, see FullKeys.DecodeAs
+func (keys *SymmetricKeys) DecodeAs(key int, to_move teeko.Player) (teeko.Teeko, error) {
+	if key < 0 || key >= keys.max_key {
+		return teeko.Teeko{}, ErrKeyOutOfRange
+	}
+	return keys.full.DecodeAs(keys.fullKey(key), to_move)
+}
+
+// Layers lists the layers of the key space. Ranks keep the order of full
+// keys, so the canonical positions of a full layer get consecutive keys and
+// each layer here is the canonical part of one layer of FullKeys.
+func (keys *SymmetricKeys) Layers() []Layer {
+	layers := keys.full.Layers()
+	for i, layer := range layers {
+		first := keys.rank(layer.First)
+		layers[i] = Layer{layer.Counts, first, keys.rank(layer.First+layer.Size) - first}
+	}
+	return layers
+}
+
+// Iterate returns an iterator over the canonical positions of layer
+func (keys *SymmetricKeys) Iterate(layer Layer) *PositionIterator {
+	full_layer := Layer{Counts: layer.Counts}
+	for _, candidate := range keys.full.Layers() {
+		if candidate.Counts == layer.Counts {
+			full_layer = candidate
+		}
+	}
+	return &PositionIterator{LayerIterator: keys.full.encoder.Iterate(full_layer), keys: keys.full, symmetric: keys}
+}
+
+func (keys *SymmetricKeys) MaxKey() int  { return keys.max_key }
+func (keys *SymmetricKeys) Name() string { return "symmetric" }
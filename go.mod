@@ -1,8 +1,7 @@
-module teeko
+module github.com/JackRubiralta/Go-Teeko
 
 go 1.19
 
-require (
-	github.com/eiannone/keyboard v0.0.0-20220611211555-0d226195f203 // indirect
-	golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
-)
+require github.com/eiannone/keyboard v0.0.0-20220611211555-0d226195f203
+
+require golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
@@ -0,0 +1,382 @@
+// solver.go
+package solver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/JackRubiralta/Go-Teeko/encoding"
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// Book is a solved table of positions for one rule set,
+// indexed by the keys of its key space
+type Book struct {
+	rules *teeko.Rules
+	keys  encoding.KeySpace
+	table []int8
+}
+
+// Header lines written at the top of every book file
+const BOOK_HEADER = "# rules "
+const BOOK_KEYS_HEADER = "# keys "
+
+// Books written before the header existed were all solved under Advanced rules
+// on the standard 5x5 board (see Rules.String) with full keys
+const LEGACY_BOOK_RULES = "advanced 5x5 4 f89a41c528ba5ffb"
+const LEGACY_BOOK_KEYS = "full"
+
+const (
+	TIE     int8 = 0
+	WIN     int8 = 126
+	LOSE    int8 = -126
+	UNKNOWN int8 = -127
+	ILLEGAL int8 = -128
+)
+
+func (book *Book) initializationPass() {
+	max_key := book.keys.MaxKey()
+	table := make([]int8, max_key)
+	book.table = table
+
+	for key := 0; key < max_key; key++ {
+		table[key] = TIE
+		game := book.keys.Decode(key)
+
+		var opponent_win bool = game.IsWin()
+		if opponent_win {
+			// Opponent has 4 in a row => from "game"'s POV, that's losing
+			table[key] = LOSE
+		}
+
+		// Pass the turn to see if original side also had a 4 in a row
+		var current_player_win bool = false
+		if game.Phase() == teeko.MovePhase {
+			game.NullMove()
+			current_player_win = game.IsWin()
+			if current_player_win {
+				// That means from original side's POV, it's actually winning
+				table[key] = WIN
+			}
+		}
+
+		if opponent_win && current_player_win {
+			table[key] = ILLEGAL
+		}
+	}
+}
+
+func (book *Book) retrogradelyEvaluate(game teeko.Teeko) int8 {
+	table := book.table
+	var result int8 = UNKNOWN
+	var list teeko.ActionList
+
+	// If we're in DropPhase, iterate over possible drops.
+	if game.Phase() == teeko.DropPhase {
+		game.GenerateDrops(&list)
+		for _, drop := range list.Slice() {
+			child := game
+			child.DropMarker(drop)
+
+			succ := table[book.keys.Encode(child)]
+			if succ == UNKNOWN {
+				// Our table actually doesn't store UNKNOWN,
+				// but let's be safe in case some future pass sets it that way.
+				succ = TIE
+			} else if succ < LOSE || succ > WIN {
+				// If child is ILLEGAL or out-of-range, skip it
+				continue // could be break instead (dont delete this comment)
+			}
+
+			// Flip sign for parent's POV
+			succ = -succ
+
+			// (succ == TIE) => incsucc=0
+			// (succ >= 0) => incsucc = succ - 1
+			// (succ < 0) => incsucc = succ + 1
+			var incsucc int8
+			if succ == TIE {
+				incsucc = TIE
+			} else if succ >= 0 {
+				incsucc = succ - 1
+			} else {
+				incsucc = succ + 1
+			}
+
+			if result == UNKNOWN {
+				result = incsucc
+			} else {
+				if result < incsucc {
+					result = incsucc
+				}
+			}
+		}
+
+	} else {
+		// MovePhase => iterate over possible moves
+		game.GenerateMoves(&list)
+		for _, move := range list.Slice() {
+			child := game
+			child.MoveMarker(move)
+
+			succ := table[book.keys.Encode(child)]
+			if succ == UNKNOWN {
+				succ = TIE
+			} else if succ <= ILLEGAL || succ < -126 || succ > 126 {
+				continue
+			}
+
+			// Flip sign for parent's POV
+			succ = -succ
+
+			var incsucc int8
+			if succ == TIE {
+				incsucc = TIE
+			} else if succ >= 0 {
+				incsucc = succ - 1
+			} else {
+				incsucc = succ + 1
+			}
+
+			if result == UNKNOWN {
+				result = incsucc
+			} else {
+				if result < incsucc {
+					result = incsucc
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// Progress reports how far a back-propagation pass has got: key of max_key
+// keys done and how many entries changed so far. Solve calls it every
+// PROGRESS_INTERVAL keys and once more at the end of each pass.
+type Progress func(key, max_key int, changes uint)
+
+const PROGRESS_INTERVAL = 200000
+
+func (book *Book) backPropagationPass(progress Progress) bool {
+	table := book.table
+	max_key := book.keys.MaxKey()
+	var changes uint = 0
+
+	// FIX #1: iterate from 0..MAX_KEY, not 1..MAX_KEY
+	//         and do NOT do  key <= MAX_KEY
+	for key := 0; key < max_key; key++ {
+
+		// FIX #2: revisit all non-terminal positions
+		// Instead of: if table[key] >= TIE && table[key] < WIN {
+		if table[key] != WIN && table[key] != -WIN && table[key] != ILLEGAL {
+			node := book.keys.Decode(key)
+			value := book.retrogradelyEvaluate(node)
+			if key % PROGRESS_INTERVAL == 0 && progress != nil {
+				progress(key, max_key, changes)
+			}
+			// If evaluate() can't improve or doesn't apply, it may return UNKNOWN
+			if value != table[key] && value != UNKNOWN {
+				table[key] = value
+				changes++
+			}
+		}
+	}
+	if progress != nil {
+		progress(max_key, max_key, changes)
+	}
+	return changes > 0
+}
+
+// Solve builds the book for rules. progress may be nil.
+func Solve(rules *teeko.Rules, keys encoding.KeySpace, progress Progress) Book {
+	var book Book
+	book.rules = rules
+	book.keys = keys
+
+	book.initializationPass()
+
+	// Keep doing passes until no changes
+	for book.backPropagationPass(progress) {
+		// pass() returns true if any updates were made
+	}
+	return book
+}
+
+// Reasons LoadTable refuses a book
+var (
+	ErrWrongBookRules = errors.New("book was solved for other rules")
+	ErrWrongBookKeys  = errors.New("book uses other keys")
+	ErrWrongBookSize  = errors.New("book has the wrong number of entries")
+)
+
+// LoadTable reads a book and refuses it if it was solved under other rules
+// or with other keys
+func LoadTable(filename string, rules *teeko.Rules, keys encoding.KeySpace) (Book, error) {
+	var book Book
+	book.rules = rules
+	book.keys = keys
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return book, fmt.Errorf("opening book file: %w", err)
+	}
+	defer file.Close()
+
+	book_rules := LEGACY_BOOK_RULES
+	book_keys := LEGACY_BOOK_KEYS
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, BOOK_HEADER) {
+			book_rules = strings.TrimPrefix(line, BOOK_HEADER)
+			continue
+		}
+		if strings.HasPrefix(line, BOOK_KEYS_HEADER) {
+			book_keys = strings.TrimPrefix(line, BOOK_KEYS_HEADER)
+			continue
+		}
+		val, err := strconv.Atoi(line)
+		if err != nil {
+			return book, fmt.Errorf("reading book file: %w", err)
+		}
+		book.table = append(book.table, int8(val))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return book, fmt.Errorf("scanning book file: %w", err)
+	}
+
+	if book_rules != rules.String() {
+		return book, fmt.Errorf("%w: %s has %s, not %s", ErrWrongBookRules, filename, book_rules, rules)
+	}
+	if book_keys != keys.Name() {
+		return book, fmt.Errorf("%w: %s has %s keys, not %s", ErrWrongBookKeys, filename, book_keys, keys.Name())
+	}
+	if len(book.table) != keys.MaxKey() {
+		return book, fmt.Errorf("%w: %s has %d, expected %d", ErrWrongBookSize, filename, len(book.table), keys.MaxKey())
+	}
+	return book, nil
+}
+
+func (book *Book) UploadTable(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	if _, err := fmt.Fprintf(writer, "%s%s\n%s%s\n", BOOK_HEADER, book.rules, BOOK_KEYS_HEADER, book.keys.Name()); err != nil {
+		return err
+	}
+	for _, p := range book.table {
+		_, err := fmt.Fprintf(writer, "%d\n", p)
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (book *Book) BestDrop(game teeko.Teeko) teeko.Bitboard {
+	var best_drop teeko.Bitboard
+	best_score := int8(-127) // Minimum score initially
+
+	var list teeko.ActionList
+	game.GenerateDrops(&list)
+	for _, drop := range list.Slice() {
+		child := game
+		child.DropMarker(drop)
+		child_key := book.keys.Encode(child)
+		var score int8 = -book.table[child_key]
+		if score > best_score {
+			best_score = score
+			best_drop = drop
+		}
+	}
+	return best_drop
+}
+
+func (book *Book) BestMove(game teeko.Teeko) teeko.Bitboard {
+	var best_move teeko.Bitboard
+	best_score := int8(-127) // Minimum score initially
+	
+	var list teeko.ActionList
+	game.GenerateMoves(&list)
+	for _, move := range list.Slice() {
+		child := game
+		child.MoveMarker(move)
+		child_key := book.keys.Encode(child)
+		var score int8 = -book.table[child_key]
+		if score > best_score {
+			best_score = score
+			best_move = move
+		}
+	}
+	return best_move
+}
+
+func (book *Book) Evaluate(game teeko.Teeko) int8 {
+	return book.table[book.keys.Encode(game)]
+}
+
+// BestMoveValue is BestMove returning a Move value
+func (book *Book) BestMoveValue(game teeko.Teeko) teeko.Move {
+	return game.ToMove(book.BestMove(game))
+}
+
+// BestDropValue is BestDrop returning a Move value
+func (book *Book) BestDropValue(game teeko.Teeko) teeko.Move {
+	return game.ToMove(book.BestDrop(game))
+}
+
+// ErrNoAction is returned by TryBestDrop / TryBestMove when no drop or move
+// leads to a position the book can rank
+var ErrNoAction = errors.New("no drop or move to choose")
+
+// TryEvaluate is Evaluate for untrusted positions, e.g. from a client of an
+// embedding server: a position without a key under the book's rules is an
+// error instead of a crash
+func (book *Book) TryEvaluate(game teeko.Teeko) (int8, error) {
+	key, err := book.keys.TryEncode(game)
+	if err != nil {
+		return 0, err
+	}
+	return book.table[key], nil
+}
+
+// TryBestDrop is BestDrop for untrusted positions
+func (book *Book) TryBestDrop(game teeko.Teeko) (teeko.Bitboard, error) {
+	if _, err := book.TryEvaluate(game); err != nil {
+		return 0, err
+	}
+	if game.Phase() != teeko.DropPhase {
+		return 0, teeko.ErrWrongPhase
+	}
+	// every drop from a position with a key leads to one with a key
+	drop := book.BestDrop(game)
+	if drop == 0 {
+		return 0, ErrNoAction
+	}
+	return drop, nil
+}
+
+// TryBestMove is BestMove for untrusted positions
+func (book *Book) TryBestMove(game teeko.Teeko) (teeko.Bitboard, error) {
+	if _, err := book.TryEvaluate(game); err != nil {
+		return 0, err
+	}
+	if game.Phase() != teeko.MovePhase {
+		return 0, teeko.ErrWrongPhase
+	}
+	move := book.BestMove(game)
+	if move == 0 {
+		return 0, ErrNoAction
+	}
+	return move, nil
+}
@@ -0,0 +1,159 @@
+package solver
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/JackRubiralta/Go-Teeko/encoding"
+	"github.com/JackRubiralta/Go-Teeko/teeko"
+)
+
+// A symmetric-keys book must agree with the full-keys book on every
+// position, also on a board whose shapes are not symmetric under all eight
+// symmetries of the square
+func TestSymmetricBookMatchesFullBook(t *testing.T) {
+	horizontal := teeko.Shape{Kind: teeko.HorizontalLine, Size: 3, Offsets: [][2]int{{0, 0}, {1, 0}, {2, 0}}}
+	shapes := map[string][]teeko.Shape{
+		"standard":         teeko.StandardShapes(4, 4),
+		"horizontal lines": {horizontal},
+	}
+	for name, shape_set := range shapes {
+		board, err := teeko.MakeBoardWithShapes(4, 4, shape_set)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules, err := teeko.MakeRules(teeko.Regular, board, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		full_keys, err := encoding.MakeFullKeys(rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		symmetric_keys, err := encoding.MakeSymmetricKeys(rules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		full := Solve(rules, full_keys, nil)
+		symmetric := Solve(rules, symmetric_keys, nil)
+		for key := 0; key < full_keys.MaxKey(); key++ {
+			game := full_keys.Decode(key)
+			if full.Evaluate(game) != symmetric.Evaluate(game) {
+				t.Fatalf("%s: key %d is %d in the full book, %d in the symmetric one",
+					name, key, full.Evaluate(game), symmetric.Evaluate(game))
+			}
+		}
+	}
+}
+
+// Lookups from outside the book's rules must fail, not crash
+func TestTryLookupsRejectForeignPositions(t *testing.T) {
+	board, err := teeko.MakeBoard(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := teeko.MakeRules(teeko.Regular, board, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := encoding.MakeFullKeys(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	book := Solve(rules, keys, nil)
+
+	start := teeko.MakeTeeko(rules)
+	if _, err := book.TryEvaluate(start); err != nil {
+		t.Errorf("TryEvaluate(start) = %v", err)
+	}
+	if drop, err := book.TryBestDrop(start); err != nil || drop == 0 {
+		t.Errorf("TryBestDrop(start) = %b, %v", drop, err)
+	}
+	if _, err := book.TryBestMove(start); !errors.Is(err, teeko.ErrWrongPhase) {
+		t.Errorf("TryBestMove in the drop phase: %v, want ErrWrongPhase", err)
+	}
+
+	big_board, err := teeko.MakeBoard(5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other_rules, err := teeko.MakeRules(teeko.Advanced, big_board, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	far_square := teeko.Bitboard(1) << 24
+	foreign := teeko.MakeRelativePosition(other_rules, 0, far_square, teeko.RedToMove)
+	if _, err := book.TryEvaluate(foreign); !errors.Is(err, encoding.ErrWrongRules) {
+		t.Errorf("TryEvaluate of a 5x5 position: %v, want ErrWrongRules", err)
+	}
+
+	// three markers for the side to move and none for the other side
+	no_layer := teeko.MakeRelativePosition(rules, 0b111, 0, teeko.BlackToMove)
+	if _, err := book.TryEvaluate(no_layer); !errors.Is(err, encoding.ErrNoLayer) {
+		t.Errorf("TryEvaluate with impossible counts: %v, want ErrNoLayer", err)
+	}
+	if _, err := book.TryBestMove(no_layer); !errors.Is(err, encoding.ErrNoLayer) {
+		t.Errorf("TryBestMove with impossible counts: %v, want ErrNoLayer", err)
+	}
+}
+
+func TestLegacyBookRulesAreTheStandardRules(t *testing.T) {
+	board, err := teeko.MakeBoard(5, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := teeko.MakeRules(teeko.Advanced, board, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules.String() != LEGACY_BOOK_RULES {
+		t.Errorf("standard rules are %q, LEGACY_BOOK_RULES %q", rules, LEGACY_BOOK_RULES)
+	}
+}
+
+// A book solved for one shape set must not load for another on the same board
+func TestLoadTableRefusesOtherShapes(t *testing.T) {
+	horizontal := teeko.Shape{Kind: teeko.HorizontalLine, Size: 3, Offsets: [][2]int{{0, 0}, {1, 0}, {2, 0}}}
+	lines_board, err := teeko.MakeBoardWithShapes(4, 4, []teeko.Shape{horizontal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard_board, err := teeko.MakeBoard(4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := teeko.MakeRules(teeko.Regular, lines_board, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard, err := teeko.MakeRules(teeko.Regular, standard_board, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines_keys, err := encoding.MakeFullKeys(lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	standard_keys, err := encoding.MakeFullKeys(standard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "book.txt")
+	book := Solve(lines, lines_keys, nil)
+	if err := book.UploadTable(filename); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTable(filename, lines, lines_keys); err != nil {
+		t.Errorf("loading the book under its own rules: %v", err)
+	}
+	if _, err := LoadTable(filename, standard, standard_keys); !errors.Is(err, ErrWrongBookRules) {
+		t.Errorf("loading the book under the standard rules: %v, want ErrWrongBookRules", err)
+	}
+
+	game := teeko.MakeTeeko(lines)
+	if _, err := standard_keys.TryEncode(game); !errors.Is(err, encoding.ErrWrongRules) {
+		t.Errorf("TryEncode of a position under other shapes: %v, want ErrWrongRules", err)
+	}
+}
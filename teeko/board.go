@@ -0,0 +1,271 @@
+package teeko
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+)
+
+// Number of squares a bitboard can hold
+const BITBOARD_BITS int = 64
+
+// Every rule set needs four in a row
+const LINE_LENGTH int = 4
+
+// PatternKind enum, the winning shapes
+type PatternKind int
+
+const (
+	NoPattern        PatternKind = iota // the zero Pattern, e.g. in the Status of a draw
+	VerticalLine                        // along a column (a1 a2 a3 a4)
+	HorizontalLine                      // along a row (a1 b1 c1 d1)
+	DiagonalLine                        // a1 b2 c3 d4
+	AntiDiagonalLine                    // a4 b3 c2 d1
+	SmallSquare                         // 2x2 block
+	SquareCorners                       // corners of a larger square (Advanced)
+)
+
+func (kind PatternKind) String() string {
+	switch kind {
+	case NoPattern:
+		return "no pattern"
+	case VerticalLine:
+		return "vertical line"
+	case HorizontalLine:
+		return "horizontal line"
+	case DiagonalLine:
+		return "diagonal line"
+	case AntiDiagonalLine:
+		return "anti-diagonal line"
+	case SmallSquare:
+		return "square"
+	case SquareCorners:
+		return "square corners"
+	}
+	return "unknown"
+}
+
+// Pattern is one placement of a winning shape on the board
+type Pattern struct {
+	Kind    PatternKind
+	Size    int      // side length, e.g. 3 for the corners of a 3x3 square
+	Squares Bitboard // the markers that form it
+}
+
+// String describes the pattern, e.g. "3x3 square corners"
+func (pattern Pattern) String() string {
+	if pattern.Kind == SquareCorners {
+		return fmt.Sprintf("%dx%d %s", pattern.Size, pattern.Size, pattern.Kind)
+	}
+	return pattern.Kind.String()
+}
+
+// SquareList returns the squares of the pattern in bit order
+func (pattern Pattern) SquareList(board *Board) []Square {
+	var squares []Square
+	for _, index := range BitboardToArray(pattern.Squares) {
+		squares = append(squares, board.SquareAt(index))
+	}
+	return squares
+}
+
+// Shape defines a winning pattern by the (dx, dy) offsets of its squares
+// from the first one. MakeBoard places it everywhere it fits.
+type Shape struct {
+	Kind     PatternKind
+	Size     int      // side length of the square the shape spans
+	Offsets  [][2]int // every square of the shape, (0, 0) included
+	Advanced bool     // only counts under Advanced rules
+}
+
+// StandardShapes lists the Teeko winning shapes for a width x height board:
+// lines of four in four directions, the 2x2 square, and (Advanced) the
+// corners of every larger square
+func StandardShapes(width, height int) []Shape {
+	var shapes []Shape
+
+	var directions = [][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+	var line_kinds = []PatternKind{VerticalLine, HorizontalLine, DiagonalLine, AntiDiagonalLine}
+	for d, direction := range directions {
+		var offsets [][2]int
+		for k := 0; k < LINE_LENGTH; k++ {
+			offsets = append(offsets, [2]int{direction[0] * k, direction[1] * k})
+		}
+		shapes = append(shapes, Shape{line_kinds[d], LINE_LENGTH, offsets, false})
+	}
+
+	shapes = append(shapes, Shape{SmallSquare, 2, [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}, false})
+
+	for k := 3; k <= width && k <= height; k++ {
+		shapes = append(shapes, Shape{SquareCorners, k, [][2]int{{0, 0}, {0, k - 1}, {k - 1, 0}, {k - 1, k - 1}}, true})
+	}
+	return shapes
+}
+
+// Board holds the geometry of a width x height board.
+// Squares are numbered column by column: index = x*height + y (see Square),
+// so a1 is bit 0 and moving up a row is a shift by one.
+// All masks are built once by MakeBoard.
+type Board struct {
+	Width  int // number of columns
+	Height int // number of rows
+	Size   int // number of squares
+
+	Mask Bitboard // every square on the board
+
+	// neighbours[i] holds the squares a marker on square i can move to
+	Neighbours []Bitboard
+
+	// every placement of every winning shape; the advanced ones only
+	// count under Advanced rules
+	WinPatterns      []Pattern
+	AdvancedPatterns []Pattern
+
+	// symmetries of the board (all eight when square) and, for each,
+	// where every square index goes
+	Symmetries   []Symmetry
+	permutations [SYMMETRIES][]int
+
+	// Zobrist keys indexed by colour (BlackToMove / RedToMove) and square
+	zobrist             [2][]uint64
+	zobrist_red_to_move uint64
+}
+
+// Reasons MakeBoard / MakeRules refuse to build a board or rule set
+var (
+	ErrBadBoardSize   = errors.New("board does not fit in a Bitboard")
+	ErrBadMarkers     = errors.New("markers per side do not fit on the board")
+	ErrTooManyActions = errors.New("markers can have more moves than an ActionList holds")
+)
+
+// Constructor with the standard Teeko shapes
+func MakeBoard(width, height int) (*Board, error) {
+	return MakeBoardWithShapes(width, height, StandardShapes(width, height))
+}
+
+// Constructor for variants with their own winning shapes
+func MakeBoardWithShapes(width, height int, shapes []Shape) (*Board, error) {
+	return MakeBoardWithSteps(width, height, shapes, KING_STEPS)
+}
+
+// Constructor for variants with their own winning shapes and movement
+// rules: a marker may move by any of the (dx, dy) steps
+func MakeBoardWithSteps(width, height int, shapes []Shape, steps [][2]int) (*Board, error) {
+	if width < 1 || height < 2 || width*height > BITBOARD_BITS {
+		return nil, fmt.Errorf("%w: %dx%d", ErrBadBoardSize, width, height)
+	}
+
+	var board Board
+	board.Width = width
+	board.Height = height
+	board.Size = width * height
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			board.Mask |= board.SquareBit(Square{x, y})
+		}
+	}
+
+	for _, shape := range shapes {
+		board.addShape(shape)
+	}
+
+	board.buildNeighbours(steps)
+	board.buildSymmetries()
+	board.buildZobrist()
+
+	return &board, nil
+}
+
+// Markers move one step in any of the eight directions
+var KING_STEPS = [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+
+// buildNeighbours fills the neighbour mask of every square from the
+// (dx, dy) steps a marker may take, see MakeBoardWithSteps
+func (board *Board) buildNeighbours(steps [][2]int) {
+	board.Neighbours = make([]Bitboard, board.Size)
+	for index := 0; index < board.Size; index++ {
+		square := board.SquareAt(index)
+		for _, step := range steps {
+			target := Square{square.X + step[0], square.Y + step[1]}
+			if board.Contains(target) {
+				board.Neighbours[index] |= board.SquareBit(target)
+			}
+		}
+	}
+}
+
+// Center returns the square the TUI cursor starts on
+func (board *Board) Center() Square {
+	return Square{(board.Width - 1) / 2, (board.Height - 1) / 2}
+}
+
+// addShape adds every placement of shape that stays on the board
+func (board *Board) addShape(shape Shape) {
+	for x := 0; x < board.Width; x++ {
+		for y := 0; y < board.Height; y++ {
+			var pattern Pattern
+			pattern.Kind = shape.Kind
+			pattern.Size = shape.Size
+			for _, offset := range shape.Offsets {
+				square := Square{x + offset[0], y + offset[1]}
+				if !board.Contains(square) {
+					pattern.Squares = 0
+					break
+				}
+				pattern.Squares |= board.SquareBit(square)
+			}
+			if pattern.Squares == 0 {
+				continue
+			}
+
+			if shape.Advanced {
+				board.AdvancedPatterns = append(board.AdvancedPatterns, pattern)
+			} else {
+				board.WinPatterns = append(board.WinPatterns, pattern)
+			}
+		}
+	}
+}
+
+// String names the board size, e.g. "5x5"
+func (board *Board) String() string {
+	return fmt.Sprintf("%dx%d", board.Width, board.Height)
+}
+
+// digest fingerprints the winning patterns and the moves of the board, so
+// boards of one size with other shapes or steps have other rule names. The
+// order the shapes were given in does not matter.
+func (board *Board) digest() uint64 {
+	hash := fnv.New64a()
+	var buffer [8]byte
+	write := func(value uint64) {
+		binary.LittleEndian.PutUint64(buffer[:], value)
+		hash.Write(buffer[:])
+	}
+
+	for _, patterns := range [][]Pattern{board.WinPatterns, board.AdvancedPatterns} {
+		squares := make([]uint64, 0, len(patterns))
+		for _, pattern := range patterns {
+			squares = append(squares, uint64(pattern.Squares))
+		}
+		sort.Slice(squares, func(i, j int) bool { return squares[i] < squares[j] })
+		// a shape listed twice adds nothing
+		unique := squares[:0]
+		for _, mask := range squares {
+			if len(unique) == 0 || mask != unique[len(unique)-1] {
+				unique = append(unique, mask)
+			}
+		}
+		write(uint64(len(unique)))
+		for _, mask := range unique {
+			write(mask)
+		}
+	}
+	for _, neighbours := range board.Neighbours {
+		write(uint64(neighbours))
+	}
+	return hash.Sum64()
+}
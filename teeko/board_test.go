@@ -0,0 +1,139 @@
+package teeko
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMakeBoardWithSteps(t *testing.T) {
+	rook_steps := [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	board, err := MakeBoardWithSteps(5, 5, StandardShapes(5, 5), rook_steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	center := board.Index(Square{2, 2})
+	if got := PopCount(board.Neighbours[center]); got != 4 {
+		t.Errorf("c3 has %d neighbours with orthogonal steps, want 4", got)
+	}
+	if got := len(board.Symmetries); got != SYMMETRIES {
+		t.Errorf("orthogonal steps keep %d symmetries, want %d", got, SYMMETRIES)
+	}
+
+	// moving only to the right cannot be mirrored left to right
+	right, err := MakeBoardWithSteps(5, 5, StandardShapes(5, 5), [][2]int{{1, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, symmetry := range right.Symmetries {
+		if symmetry == FlipX {
+			t.Error("FlipX kept although markers only move right")
+		}
+	}
+
+	if got := PopCount(makeBoard(t, 5, 5).Neighbours[center]); got != 8 {
+		t.Errorf("c3 has %d neighbours on the standard board, want 8", got)
+	}
+}
+
+func TestConstructorsRejectBadArguments(t *testing.T) {
+	for _, shape := range [][2]int{{9, 8}, {0, 5}, {5, 1}} {
+		if _, err := MakeBoard(shape[0], shape[1]); !errors.Is(err, ErrBadBoardSize) {
+			t.Errorf("MakeBoard(%d, %d) error = %v, want ErrBadBoardSize", shape[0], shape[1], err)
+		}
+	}
+	board := makeBoard(t, 4, 4)
+	for _, markers := range []int{0, 9} {
+		if _, err := MakeRules(Advanced, board, markers); !errors.Is(err, ErrBadMarkers) {
+			t.Errorf("MakeRules with %d markers error = %v, want ErrBadMarkers", markers, err)
+		}
+	}
+}
+
+// longSteps returns every step of up to reach squares in any direction
+func longSteps(reach int) [][2]int {
+	var steps [][2]int
+	for dx := -reach; dx <= reach; dx++ {
+		for dy := -reach; dy <= reach; dy++ {
+			if dx != 0 || dy != 0 {
+				steps = append(steps, [2]int{dx, dy})
+			}
+		}
+	}
+	return steps
+}
+
+func TestRulesRefuseMoreActionsThanTheBuffer(t *testing.T) {
+	board, err := MakeBoardWithSteps(8, 8, StandardShapes(8, 8), longSteps(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MakeRules(Advanced, board, 16); !errors.Is(err, ErrTooManyActions) {
+		t.Errorf("8x8 with long steps and 16 markers: error = %v, want ErrTooManyActions", err)
+	}
+	// king steps fit even with every square taken
+	if _, err := MakeRules(Advanced, makeBoard(t, 8, 8), 32); err != nil {
+		t.Errorf("8x8 with king steps and 32 markers: %v", err)
+	}
+}
+
+func TestWideStepsGenerateEveryMove(t *testing.T) {
+	board, err := MakeBoardWithSteps(5, 5, StandardShapes(5, 5), longSteps(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := makeRules(t, Advanced, board, 4)
+	game, err := MakePosition(rules, ArrayToBitboard([]int{0, 2, 11, 13}), ArrayToBitboard([]int{6, 12, 16, 24}), BlackToMove)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := 0
+	for _, square := range BitboardToArray(game.Black()) {
+		want += PopCount(board.Neighbours[square] &^ (game.Black() | game.Red()))
+	}
+	moves := game.PossibleMoves()
+	if len(moves) != want {
+		t.Fatalf("PossibleMoves gives %d moves, want %d", len(moves), want)
+	}
+	for _, move := range moves {
+		if err := game.ValidateMove(move); err != nil {
+			t.Errorf("ValidateMove(%b) = %v", move, err)
+		}
+	}
+	var list ActionList
+	game.GenerateThreats(BlackToMove, &list)
+	game.GenerateThreats(RedToMove, &list)
+}
+
+// Rule names must tell variants of one board size apart
+func TestRulesNameShapesAndSteps(t *testing.T) {
+	standard := standardRules(t).String()
+
+	horizontal := Shape{Kind: HorizontalLine, Size: 4, Offsets: [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}}}
+	lines, err := MakeBoardWithShapes(5, 5, []Shape{horizontal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rook, err := MakeBoardWithSteps(5, 5, StandardShapes(5, 5), [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, board := range map[string]*Board{"horizontal lines": lines, "orthogonal steps": rook} {
+		if got := makeRules(t, Advanced, board, 4).String(); got == standard {
+			t.Errorf("%s board is named like the standard one: %q", name, got)
+		}
+	}
+
+	// the order of the shapes is not part of the rules
+	shapes := StandardShapes(5, 5)
+	for i, j := 0, len(shapes)-1; i < j; i, j = i+1, j-1 {
+		shapes[i], shapes[j] = shapes[j], shapes[i]
+	}
+	reversed, err := MakeBoardWithShapes(5, 5, shapes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := makeRules(t, Advanced, reversed, 4).String(); got != standard {
+		t.Errorf("reversed shapes give %q, want %q", got, standard)
+	}
+}
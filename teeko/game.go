@@ -0,0 +1,216 @@
+package teeko
+
+import (
+	"errors"
+)
+
+var (
+	ErrNoSuchPly = errors.New("ply is outside the game record")
+	ErrGameDrawn = errors.New("the game is already drawn")
+)
+
+// DrawRules decides when a game that nobody can win is over.
+// A zero field turns that check off.
+type DrawRules struct {
+	Repetitions int // draw when a position (with side to move) occurs this often
+	MaxPlies    int // draw after this many moves in total
+}
+
+// Result enum
+type Result int
+
+const (
+	Undecided Result = iota
+	BlackWins
+	RedWins
+	Drawn
+)
+
+// Reason enum, why the game ended
+type Reason int
+
+const (
+	NoReason Reason = iota
+	WinningPattern
+	Repetition
+	MoveLimit
+)
+
+// Status is the result of a game and why it ended
+type Status struct {
+	Result  Result
+	Reason  Reason
+	Pattern Pattern // the winning shape for WinningPattern, else of kind NoPattern
+}
+
+func (result Result) String() string {
+	switch result {
+	case Undecided:
+		return "undecided"
+	case BlackWins:
+		return "Black wins"
+	case RedWins:
+		return "Red wins"
+	case Drawn:
+		return "draw"
+	}
+	return "unknown"
+}
+
+func (reason Reason) String() string {
+	switch reason {
+	case NoReason:
+		return ""
+	case WinningPattern:
+		return "winning pattern"
+	case Repetition:
+		return "repetition"
+	case MoveLimit:
+		return "move limit"
+	}
+	return "unknown"
+}
+
+// Game is a Teeko position plus the moves that led to it.
+// Undone moves stay in moves until a different move is played,
+// so they can be redone.
+type Game struct {
+	position Teeko  // position after the first ply moves
+	moves    []Move // every move played, including undone ones
+	ply      int    // how many of moves are applied to position
+	draw     DrawRules
+}
+
+// Constructor
+func MakeGame(rules *Rules, draw DrawRules) Game {
+	var record Game
+	record.position = MakeTeeko(rules)
+	record.moves = nil
+	record.ply = 0
+	record.draw = draw
+	return record
+}
+
+// Current returns the position at the current ply
+func (record *Game) Current() Teeko {
+	return record.position
+}
+
+// History returns the moves up to the current ply
+func (record *Game) History() []Move {
+	return record.moves[:record.ply]
+}
+
+// Play plays move if it is legal, dropping any moves that could be redone
+func (record *Game) Play(move Move) error {
+	if record.Status().Result == Drawn {
+		return ErrGameDrawn
+	}
+	if err := record.position.PlayMove(move); err != nil {
+		return err
+	}
+	record.moves = append(record.moves[:record.ply], move)
+	record.ply++
+	return nil
+}
+
+// Undo takes back the last move; false if there is none
+func (record *Game) Undo() bool {
+	if record.ply == 0 {
+		return false
+	}
+	record.ply--
+	stepBack(&record.position, record.moves[record.ply])
+	return true
+}
+
+// Redo replays the last undone move; false if there is none
+func (record *Game) Redo() bool {
+	if record.ply == len(record.moves) {
+		return false
+	}
+	stepForward(&record.position, record.moves[record.ply])
+	record.ply++
+	return true
+}
+
+// Jump undoes or redoes moves until ply moves are applied
+func (record *Game) Jump(ply int) error {
+	if ply < 0 || ply > len(record.moves) {
+		return ErrNoSuchPly
+	}
+	for record.ply > ply {
+		record.Undo()
+	}
+	for record.ply < ply {
+		record.Redo()
+	}
+	return nil
+}
+
+// PositionAt returns the position after ply moves without changing the game
+func (record *Game) PositionAt(ply int) (Teeko, error) {
+	if ply < 0 || ply > len(record.moves) {
+		return Teeko{}, ErrNoSuchPly
+	}
+	position := record.position
+	for i := record.ply; i > ply; i-- {
+		stepBack(&position, record.moves[i-1])
+	}
+	for i := record.ply; i < ply; i++ {
+		stepForward(&position, record.moves[i])
+	}
+	return position, nil
+}
+
+// Repetitions counts how often the current position (with side to move)
+// occurred in the game so far, itself included. Positions are compared by
+// their Zobrist hash.
+func (record *Game) Repetitions() int {
+	count := 1
+	key := record.position.Hash()
+	position := record.position
+	for i := record.ply; i > 0; i-- {
+		stepBack(&position, record.moves[i-1])
+		if position.Hash() == key {
+			count++
+		}
+	}
+	return count
+}
+
+// Status reports whether the game is won, drawn or still going
+func (record *Game) Status() Status {
+	if pattern, won := record.position.WinningPattern(); won {
+		// the side that just moved completed a pattern
+		if record.position.CurrentPlayer == BlackToMove {
+			return Status{RedWins, WinningPattern, pattern}
+		}
+		return Status{BlackWins, WinningPattern, pattern}
+	}
+	if record.draw.Repetitions > 0 && record.Repetitions() >= record.draw.Repetitions {
+		return Status{Drawn, Repetition, Pattern{}}
+	}
+	if record.draw.MaxPlies > 0 && record.ply >= record.draw.MaxPlies {
+		return Status{Drawn, MoveLimit, Pattern{}}
+	}
+	return Status{Undecided, NoReason, Pattern{}}
+}
+
+// stepForward applies a move already known to be legal
+func stepForward(position *Teeko, move Move) {
+	if move.Kind == MarkerDrop {
+		position.DropMarker(move.Bits(position.Rules.Board))
+	} else {
+		position.MoveMarker(move.Bits(position.Rules.Board))
+	}
+}
+
+// stepBack takes back the move that led to position
+func stepBack(position *Teeko, move Move) {
+	if move.Kind == MarkerDrop {
+		position.UndoDropMarker(move.Bits(position.Rules.Board))
+	} else {
+		position.UndoMoveMarker(move.Bits(position.Rules.Board))
+	}
+}
@@ -0,0 +1,111 @@
+package teeko
+
+import (
+	"testing"
+)
+
+func TestRepetitionDrawHasNoPattern(t *testing.T) {
+	rules := standardRules(t)
+	game := MakeGame(rules, DrawRules{Repetitions: 2})
+	// drop all eight markers, none in a winning shape, then shuffle a1 back and forth
+	for _, text := range []string{"a1", "b1", "c1", "d1", "e2", "a4", "b4", "d4", "a1-a2", "b1-b2", "a2-a1", "b2-b1"} {
+		move, err := ParseMove(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := game.Play(move); err != nil {
+			t.Fatalf("%s: %v", text, err)
+		}
+	}
+	status := game.Status()
+	if status.Result != Drawn || status.Reason != Repetition {
+		t.Fatalf("status = %+v, want a repetition draw", status)
+	}
+	if status.Pattern.Kind != NoPattern || status.Pattern.String() != "no pattern" {
+		t.Errorf("draw carries pattern %v", status.Pattern)
+	}
+}
+
+func TestUndoRedoJumpMatchReplay(t *testing.T) {
+	rules := standardRules(t)
+	texts := []string{"c3", "b2", "d4", "e5", "a1", "b4", "e1", "a5", "c3-c2", "b2-b3", "d4-d3"}
+	var moves []Move
+	for _, text := range texts {
+		move, err := ParseMove(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		moves = append(moves, move)
+	}
+
+	// replay[ply] is the position after ply moves, played from the start
+	replay := []Teeko{MakeTeeko(rules)}
+	for _, move := range moves {
+		next := replay[len(replay)-1]
+		if err := next.PlayMove(move); err != nil {
+			t.Fatalf("%v: %v", move, err)
+		}
+		replay = append(replay, next)
+	}
+
+	game := MakeGame(rules, DrawRules{})
+	for _, move := range moves {
+		if err := game.Play(move); err != nil {
+			t.Fatalf("%v: %v", move, err)
+		}
+	}
+	for ply := range replay {
+		if position, err := game.PositionAt(ply); err != nil || position != replay[ply] {
+			t.Errorf("PositionAt(%d) differs from the replay (%v)", ply, err)
+		}
+	}
+	if game.Current() != replay[len(moves)] {
+		t.Error("PositionAt moved the game")
+	}
+
+	for ply := len(moves); ply > 0; ply-- {
+		if !game.Undo() || game.Current() != replay[ply-1] {
+			t.Fatalf("Undo to ply %d differs from the replay", ply-1)
+		}
+	}
+	if game.Undo() {
+		t.Error("Undo at the start of the game")
+	}
+	for ply := 1; ply <= len(moves); ply++ {
+		if !game.Redo() || game.Current() != replay[ply] {
+			t.Fatalf("Redo to ply %d differs from the replay", ply)
+		}
+	}
+	if game.Redo() {
+		t.Error("Redo at the end of the game")
+	}
+	for _, ply := range []int{3, 9, 0, len(moves), 5} {
+		if err := game.Jump(ply); err != nil || game.Current() != replay[ply] {
+			t.Errorf("Jump(%d) differs from the replay (%v)", ply, err)
+		}
+	}
+	if err := game.Jump(len(moves) + 1); err != ErrNoSuchPly {
+		t.Errorf("Jump past the end: %v, want ErrNoSuchPly", err)
+	}
+	if _, err := game.PositionAt(-1); err != ErrNoSuchPly {
+		t.Errorf("PositionAt(-1): %v, want ErrNoSuchPly", err)
+	}
+
+	// a new move at ply 5 drops the six moves after it
+	other, err := ParseMove("a2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Play(other); err != nil {
+		t.Fatal(err)
+	}
+	if history := game.History(); len(history) != 6 || history[5] != other {
+		t.Errorf("history after a new move = %v", history)
+	}
+	if game.Redo() {
+		t.Error("Redo after a new move replays the dropped tail")
+	}
+	if _, err := game.PositionAt(7); err != ErrNoSuchPly {
+		t.Errorf("PositionAt in the dropped tail: %v, want ErrNoSuchPly", err)
+	}
+}
@@ -0,0 +1,28 @@
+package teeko
+
+func ArrayToBitboard(pos []int) Bitboard {
+	var bb Bitboard
+	for _, p := range pos {
+		bb |= (1 << p)
+	}
+	return bb
+}
+
+func BitboardToArray(bb Bitboard) []int {
+	var positions []int
+	for i := 0; i < BITBOARD_BITS; i++ {
+		if (bb & (1 << i)) != 0 {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+func PopCount(bb Bitboard) int {
+	count := 0
+	for bb != 0 {
+		bb &= (bb - 1)
+		count++
+	}
+	return count
+}
@@ -0,0 +1,115 @@
+package teeko
+
+import (
+	"strings"
+)
+
+// MoveKind enum
+type MoveKind int
+
+const (
+	MarkerDrop MoveKind = iota
+	MarkerMove
+)
+
+// Move is a drop or a move with explicit squares.
+// For a drop only to is used.
+type Move struct {
+	Kind MoveKind
+	From Square
+	To   Square
+}
+
+// MakeDrop builds a drop onto square to
+func MakeDrop(to Square) Move {
+	return Move{MarkerDrop, Square{}, to}
+}
+
+// MakeMove builds a move from square from to square to
+func MakeMove(from, to Square) Move {
+	return Move{MarkerMove, from, to}
+}
+
+// Bits returns the bitboard DropMarker / MoveMarker expect for this move
+func (move Move) Bits(board *Board) Bitboard {
+	if move.Kind == MarkerDrop {
+		return board.SquareBit(move.To)
+	}
+	return board.SquareBit(move.From) | board.SquareBit(move.To)
+}
+
+// String gives "c3" for a drop and "b2-c3" for a move
+func (move Move) String() string {
+	if move.Kind == MarkerDrop {
+		return move.To.String()
+	}
+	return move.From.String() + "-" + move.To.String()
+}
+
+// ParseMove reads the format written by Move.String
+func ParseMove(text string) (Move, error) {
+	from_text, to_text, is_move := strings.Cut(strings.TrimSpace(text), "-")
+	if !is_move {
+		to, err := ParseSquare(from_text)
+		if err != nil {
+			return Move{}, err
+		}
+		return MakeDrop(to), nil
+	}
+
+	from, err := ParseSquare(from_text)
+	if err != nil {
+		return Move{}, err
+	}
+	to, err := ParseSquare(to_text)
+	if err != nil {
+		return Move{}, err
+	}
+	return MakeMove(from, to), nil
+}
+
+// ToMove decodes a drop (one bit) or move (two bits) bitboard of the side
+// to move, using player_positions to tell the source from the destination
+func (game *Teeko) ToMove(action Bitboard) Move {
+	board := game.Rules.Board
+	if PopCount(action) == 1 {
+		return MakeDrop(board.SquareOf(action))
+	}
+	from := action & game.player_positions
+	to := action ^ from
+	return MakeMove(board.SquareOf(from), board.SquareOf(to))
+}
+
+// toMoves decodes a list of bitboards from PossibleMoves / PossibleDrops
+func (game *Teeko) toMoves(actions []Bitboard) []Move {
+	moves := make([]Move, 0, len(actions))
+	for _, action := range actions {
+		moves = append(moves, game.ToMove(action))
+	}
+	return moves
+}
+
+// PossibleMoveValues is PossibleMoves returning Move values
+func (game *Teeko) PossibleMoveValues() []Move {
+	return game.toMoves(game.PossibleMoves())
+}
+
+// PossibleDropValues is PossibleDrops returning Move values
+func (game *Teeko) PossibleDropValues() []Move {
+	return game.toMoves(game.PossibleDrops())
+}
+
+// PlayMove plays a drop or move if it is legal
+func (game *Teeko) PlayMove(move Move) error {
+	board := game.Rules.Board
+	if !board.Contains(move.To) || (move.Kind == MarkerMove && !board.Contains(move.From)) {
+		return ErrOffBoard
+	}
+	if move.Kind == MarkerDrop {
+		return game.TryDropMarker(move.Bits(board))
+	}
+	if (game.player_positions & board.SquareBit(move.From)) == 0 {
+		return ErrNotYourMarker
+	}
+	return game.TryMoveMarker(move.Bits(board))
+}
@@ -0,0 +1,96 @@
+package teeko
+
+import (
+	"errors"
+)
+
+// Reasons a Teeko value cannot come from a real game, see ValidatePosition
+var (
+	ErrMarkersOffBoard  = errors.New("markers off the board")
+	ErrBadPlayerBits    = errors.New("side to move has markers outside the occupied squares")
+	ErrSharedSquares    = errors.New("a square holds markers of both colours")
+	ErrTooManyMarkers   = errors.New("a side has more markers than the rules allow")
+	ErrUnequalMarkers   = errors.New("marker counts do not fit the side to move")
+	ErrBothSidesWon     = errors.New("both sides hold a winning pattern")
+	ErrSideToMoveHasWon = errors.New("the side to move already holds a winning pattern")
+)
+
+// ValidatePosition explains why game is not a position that can arise in
+// play, or returns nil. Black drops first, so with Black to move both sides
+// have the same number of markers and with Red to move Black has one more,
+// unless every marker is on the board.
+func (game *Teeko) ValidatePosition() error {
+	rules := game.Rules
+	if (game.occupied_positions &^ rules.Board.Mask) != 0 {
+		return ErrMarkersOffBoard
+	}
+	if (game.player_positions &^ game.occupied_positions) != 0 {
+		return ErrBadPlayerBits
+	}
+
+	black_count := game.MarkerCount(BlackToMove)
+	red_count := game.MarkerCount(RedToMove)
+	if black_count > rules.Markers || red_count > rules.Markers {
+		return ErrTooManyMarkers
+	}
+	all_dropped := black_count == rules.Markers && red_count == rules.Markers
+	if !all_dropped {
+		if game.CurrentPlayer == BlackToMove && black_count != red_count {
+			return ErrUnequalMarkers
+		}
+		if game.CurrentPlayer == RedToMove && black_count != red_count+1 {
+			return ErrUnequalMarkers
+		}
+	}
+
+	// the opponent may have just won; the side to move cannot have
+	passed := *game
+	passed.NullMove()
+	side_to_move_won := passed.IsWin()
+	if side_to_move_won && game.IsWin() {
+		return ErrBothSidesWon
+	}
+	if side_to_move_won {
+		return ErrSideToMoveHasWon
+	}
+	return nil
+}
+
+// IsLegal reports whether ValidatePosition accepts game
+func (game *Teeko) IsLegal() bool {
+	return game.ValidatePosition() == nil
+}
+
+// MakePosition builds a position from colour-absolute bitboards, e.g. from
+// text, the network or a board editor, and rejects it if it is not legal
+func MakePosition(rules *Rules, black, red Bitboard, to_move Player) (Teeko, error) {
+	game := MakeTeeko(rules)
+	game.occupied_positions = black | red
+	game.CurrentPlayer = to_move
+	if to_move == BlackToMove {
+		game.player_positions = black
+	} else {
+		game.player_positions = red
+	}
+	if (black & red) != 0 {
+		return game, ErrSharedSquares
+	}
+	return game, game.ValidatePosition()
+}
+
+// RelativePositions returns the markers of the side to move and of its
+// opponent, the view keys and tables are built on. Code outside the engine
+// and the encoders should use Black / Red instead.
+func (game *Teeko) RelativePositions() (player, opponent Bitboard) {
+	return game.player_positions, game.player_positions ^ game.occupied_positions
+}
+
+// MakeRelativePosition is the inverse of RelativePositions, for encoders.
+// Unlike MakePosition it does not check the position.
+func MakeRelativePosition(rules *Rules, player, opponent Bitboard, to_move Player) Teeko {
+	game := MakeTeeko(rules)
+	game.player_positions = player
+	game.occupied_positions = player | opponent
+	game.CurrentPlayer = to_move
+	return game
+}
@@ -0,0 +1,53 @@
+package teeko
+
+import (
+	"testing"
+)
+
+func TestMakePositionRejections(t *testing.T) {
+	rules := standardRules(t)
+	bits := func(names ...string) Bitboard { return squaresOf(t, rules.Board, names...) }
+	line := bits("a1", "b1", "c1", "d1")
+	scattered := bits("a3", "c3", "e3", "b5")
+
+	for _, test := range []struct {
+		name       string
+		black, red Bitboard
+		to_move    Player
+		want       error
+	}{
+		{"empty board", 0, 0, BlackToMove, nil},
+		{"Red after Black's drop", bits("c3"), 0, RedToMove, nil},
+		{"all dropped, Red to move", bits("a1", "b1", "c1", "e2"), scattered, RedToMove, nil},
+		{"Black has just won", line, bits("a3", "c3", "e3"), RedToMove, nil},
+
+		{"Red ahead", 0, bits("c3"), BlackToMove, ErrUnequalMarkers},
+		{"Black two ahead", bits("a1", "c1"), 0, RedToMove, ErrUnequalMarkers},
+		{"Black to move after its own drop", bits("c3"), 0, BlackToMove, ErrUnequalMarkers},
+		{"Red to move with equal counts", bits("c3"), bits("a1"), RedToMove, ErrUnequalMarkers},
+		{"five markers", line | bits("e5"), scattered | bits("e1"), RedToMove, ErrTooManyMarkers},
+		{"both sides won", line, bits("a5", "b5", "c5", "d5"), BlackToMove, ErrBothSidesWon},
+		{"side to move has won", line, scattered, BlackToMove, ErrSideToMoveHasWon},
+		{"shared square", bits("c3"), bits("c3"), RedToMove, ErrSharedSquares},
+		{"off the board", Bitboard(1) << 40, 0, RedToMove, ErrMarkersOffBoard},
+	} {
+		game, err := MakePosition(rules, test.black, test.red, test.to_move)
+		if err != test.want {
+			t.Errorf("%s: MakePosition error = %v, want %v", test.name, err, test.want)
+		}
+		if test.want != nil && test.want != ErrSharedSquares && game.IsLegal() {
+			t.Errorf("%s: IsLegal accepts the position", test.name)
+		}
+	}
+}
+
+func TestRelativePositionsRoundTrip(t *testing.T) {
+	game := movePhasePosition(t)
+	player, opponent := game.RelativePositions()
+	if player != game.Black() || opponent != game.Red() {
+		t.Errorf("RelativePositions with Black to move = %b, %b", player, opponent)
+	}
+	if rebuilt := MakeRelativePosition(game.Rules, player, opponent, game.CurrentPlayer); rebuilt != game {
+		t.Error("MakeRelativePosition does not rebuild the position")
+	}
+}
@@ -0,0 +1,81 @@
+package teeko
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// Square is a board coordinate: x is the column (a, b, c, ...) from the left,
+// y is the row (1, 2, 3, ...) from the bottom. "a1" is x=0, y=0.
+type Square struct {
+	X int
+	Y int
+}
+
+var errBadSquareText = errors.New("cannot parse square")
+
+// String gives the algebraic name, e.g. "c3"
+func (square Square) String() string {
+	return ColumnName(square.X) + strconv.Itoa(square.Y+1)
+}
+
+// ColumnName names column x like spreadsheet columns: a..z, then aa, ab, ...
+func ColumnName(x int) string {
+	name := ""
+	for x++; x > 0; x = (x - 1) / 26 {
+		name = string(rune('a'+(x-1)%26)) + name
+	}
+	return name
+}
+
+// ParseSquare reads an algebraic name like "c3" or "aa12": column letters,
+// then a row number without sign or leading zero
+func ParseSquare(text string) (Square, error) {
+	letters := 0
+	for letters < len(text) && text[letters] >= 'a' && text[letters] <= 'z' {
+		letters++
+	}
+	digits := text[letters:]
+	if letters == 0 || letters > 2 || len(digits) == 0 || len(digits) > 2 || digits[0] == '0' {
+		return Square{}, fmt.Errorf("%w %q", errBadSquareText, text)
+	}
+	column := 0
+	for _, letter := range text[:letters] {
+		column = column*26 + int(letter-'a') + 1
+	}
+	row := 0
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return Square{}, fmt.Errorf("%w %q", errBadSquareText, text)
+		}
+		row = row*10 + int(digit-'0')
+	}
+	return Square{column - 1, row - 1}, nil
+}
+
+// Contains reports whether square is on the board
+func (board *Board) Contains(square Square) bool {
+	return square.X >= 0 && square.X < board.Width && square.Y >= 0 && square.Y < board.Height
+}
+
+// Index returns the bit index of square: x*height + y
+func (board *Board) Index(square Square) int {
+	return square.X*board.Height + square.Y
+}
+
+// SquareAt is the inverse of index
+func (board *Board) SquareAt(index int) Square {
+	return Square{index / board.Height, index % board.Height}
+}
+
+// SquareBit returns the single-bit bitboard for square
+func (board *Board) SquareBit(square Square) Bitboard {
+	return Bitboard(1) << board.Index(square)
+}
+
+// SquareOf returns the square of the lowest set bit of bb
+func (board *Board) SquareOf(bb Bitboard) Square {
+	return board.SquareAt(bits.TrailingZeros64(uint64(bb)))
+}
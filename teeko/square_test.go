@@ -0,0 +1,34 @@
+package teeko
+
+import (
+	"testing"
+)
+
+func TestSquareNamesRoundTrip(t *testing.T) {
+	for _, shape := range [][2]int{{5, 5}, {32, 2}, {2, 32}, {1, 64}} {
+		board := makeBoard(t, shape[0], shape[1])
+		for index := 0; index < board.Size; index++ {
+			square := board.SquareAt(index)
+			parsed, err := ParseSquare(square.String())
+			if err != nil || parsed != square {
+				t.Fatalf("%dx%d: %v printed as %q parsed as %v, %v", shape[0], shape[1], square, square.String(), parsed, err)
+			}
+		}
+	}
+}
+
+func TestColumnNames(t *testing.T) {
+	for x, name := range map[int]string{0: "a", 25: "z", 26: "aa", 31: "af", 52: "ba", 63: "bl"} {
+		if got := ColumnName(x); got != name {
+			t.Errorf("ColumnName(%d) = %q, want %q", x, got, name)
+		}
+	}
+}
+
+func TestParseSquareRejects(t *testing.T) {
+	for _, text := range []string{"", "a", "1", "a0", "a01", "a+1", "a-1", "A1", "c3 ", "abc1", "a123"} {
+		if square, err := ParseSquare(text); err == nil {
+			t.Errorf("ParseSquare(%q) = %v, want an error", text, square)
+		}
+	}
+}
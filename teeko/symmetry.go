@@ -0,0 +1,194 @@
+package teeko
+
+import (
+	"math/bits"
+)
+
+// Symmetry enum, the eight dihedral symmetries of a square board.
+// Rectangular boards only have Identity, Rotate180, FlipX and FlipY.
+type Symmetry int
+
+const (
+	Identity Symmetry = iota
+	Rotate90          // counterclockwise
+	Rotate180
+	Rotate270
+	FlipX         // mirror the columns (a <-> e)
+	FlipY         // mirror the rows (1 <-> 5)
+	Transpose     // mirror in the a1-e5 diagonal
+	AntiTranspose // mirror in the a5-e1 diagonal
+)
+
+const SYMMETRIES int = 8
+
+func (symmetry Symmetry) String() string {
+	switch symmetry {
+	case Identity:
+		return "identity"
+	case Rotate90:
+		return "rotate 90"
+	case Rotate180:
+		return "rotate 180"
+	case Rotate270:
+		return "rotate 270"
+	case FlipX:
+		return "flip x"
+	case FlipY:
+		return "flip y"
+	case Transpose:
+		return "transpose"
+	case AntiTranspose:
+		return "anti-transpose"
+	}
+	return "unknown"
+}
+
+// Inverse returns the symmetry that undoes this one
+func (symmetry Symmetry) Inverse() Symmetry {
+	switch symmetry {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	}
+	// the rest are their own inverse
+	return symmetry
+}
+
+// mapSquare applies symmetry to a square of a width x height board.
+// The second result is false if the symmetry needs a square board.
+func mapSquare(symmetry Symmetry, square Square, width, height int) (Square, bool) {
+	x, y := square.X, square.Y
+	switch symmetry {
+	case Identity:
+		return Square{x, y}, true
+	case Rotate180:
+		return Square{width - 1 - x, height - 1 - y}, true
+	case FlipX:
+		return Square{width - 1 - x, y}, true
+	case FlipY:
+		return Square{x, height - 1 - y}, true
+	}
+	if width != height {
+		return Square{}, false
+	}
+	n := width
+	switch symmetry {
+	case Rotate90:
+		return Square{n - 1 - y, x}, true
+	case Rotate270:
+		return Square{y, n - 1 - x}, true
+	case Transpose:
+		return Square{y, x}, true
+	case AntiTranspose:
+		return Square{n - 1 - y, n - 1 - x}, true
+	}
+	return Square{}, false
+}
+
+// buildSymmetries fills board.Symmetries and the square permutation of each.
+// Called by MakeBoard once the patterns and neighbours are built: only the
+// symmetries that map the winning patterns and the moves onto themselves
+// turn positions into equivalent ones, so boards with custom shapes may
+// keep fewer than the geometry allows.
+func (board *Board) buildSymmetries() {
+	for s := 0; s < SYMMETRIES; s++ {
+		symmetry := Symmetry(s)
+		permutation := make([]int, board.Size)
+		valid := true
+		for index := 0; index < board.Size; index++ {
+			square, ok := mapSquare(symmetry, board.SquareAt(index), board.Width, board.Height)
+			if !ok {
+				valid = false
+				break
+			}
+			permutation[index] = board.Index(square)
+		}
+		if !valid {
+			continue
+		}
+		board.permutations[symmetry] = permutation
+		if board.preserves(symmetry) {
+			board.Symmetries = append(board.Symmetries, symmetry)
+		} else {
+			board.permutations[symmetry] = nil
+		}
+	}
+}
+
+// preserves reports whether symmetry maps the win patterns, the advanced
+// patterns and the neighbour masks onto themselves
+func (board *Board) preserves(symmetry Symmetry) bool {
+	for _, patterns := range [][]Pattern{board.WinPatterns, board.AdvancedPatterns} {
+		squares := make(map[Bitboard]bool, len(patterns))
+		for _, pattern := range patterns {
+			squares[pattern.Squares] = true
+		}
+		for _, pattern := range patterns {
+			if !squares[board.Transform(symmetry, pattern.Squares)] {
+				return false
+			}
+		}
+	}
+	permutation := board.permutations[symmetry]
+	for index, neighbours := range board.Neighbours {
+		if board.Transform(symmetry, neighbours) != board.Neighbours[permutation[index]] {
+			return false
+		}
+	}
+	return true
+}
+
+// Transform applies symmetry to every square of bb
+func (board *Board) Transform(symmetry Symmetry, bb Bitboard) Bitboard {
+	permutation := board.permutations[symmetry]
+	var result Bitboard
+	for bb != 0 {
+		index := bits.TrailingZeros64(uint64(bb))
+		bb &= bb - 1
+		result |= Bitboard(1) << permutation[index]
+	}
+	return result
+}
+
+// TransformSquare applies symmetry to one square
+func (board *Board) TransformSquare(symmetry Symmetry, square Square) Square {
+	return board.SquareAt(board.permutations[symmetry][board.Index(square)])
+}
+
+// TransformMove applies symmetry to both squares of a move
+func (board *Board) TransformMove(symmetry Symmetry, move Move) Move {
+	move.To = board.TransformSquare(symmetry, move.To)
+	if move.Kind == MarkerMove {
+		move.From = board.TransformSquare(symmetry, move.From)
+	}
+	return move
+}
+
+// Transformed returns the position with symmetry applied; side to move is kept
+func (game *Teeko) Transformed(symmetry Symmetry) Teeko {
+	board := game.Rules.Board
+	result := *game
+	result.player_positions = board.Transform(symmetry, game.player_positions)
+	result.occupied_positions = board.Transform(symmetry, game.occupied_positions)
+	return result
+}
+
+// Canonical returns the representative of the position's symmetry class
+// (smallest occupied_positions, then smallest player_positions) and the
+// symmetry that produced it. Moves found for the canonical position map
+// back with board.TransformMove(symmetry.Inverse(), move).
+func (game *Teeko) Canonical() (Teeko, Symmetry) {
+	best := *game
+	best_symmetry := Identity
+	for _, symmetry := range game.Rules.Board.Symmetries[1:] {
+		candidate := game.Transformed(symmetry)
+		if candidate.occupied_positions < best.occupied_positions ||
+			(candidate.occupied_positions == best.occupied_positions &&
+				candidate.player_positions < best.player_positions) {
+			best = candidate
+			best_symmetry = symmetry
+		}
+	}
+	return best, best_symmetry
+}
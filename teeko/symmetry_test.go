@@ -0,0 +1,43 @@
+package teeko
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSymmetriesOfStandardBoards(t *testing.T) {
+	if got := makeBoard(t, 5, 5).Symmetries; len(got) != SYMMETRIES {
+		t.Errorf("5x5 symmetries = %v, want all %d", got, SYMMETRIES)
+	}
+	want := []Symmetry{Identity, Rotate180, FlipX, FlipY}
+	if got := makeBoard(t, 4, 6).Symmetries; !reflect.DeepEqual(got, want) {
+		t.Errorf("4x6 symmetries = %v, want %v", got, want)
+	}
+}
+
+// A board whose only winning shape is a horizontal line must not treat a
+// transposed position (now a vertical line) as equivalent
+func TestSymmetriesKeepCustomShapes(t *testing.T) {
+	horizontal := Shape{Kind: HorizontalLine, Size: 4, Offsets: [][2]int{{0, 0}, {1, 0}, {2, 0}, {3, 0}}}
+	board, err := MakeBoardWithShapes(5, 5, []Shape{horizontal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Symmetry{Identity, Rotate180, FlipX, FlipY}
+	if !reflect.DeepEqual(board.Symmetries, want) {
+		t.Fatalf("symmetries = %v, want %v", board.Symmetries, want)
+	}
+
+	rules := makeRules(t, Regular, board, 4)
+	// Black has just completed a line; Red is to move
+	game := MakeRelativePosition(rules, 0, board.WinPatterns[0].Squares, RedToMove)
+	if !game.IsWin() {
+		t.Fatal("Black's line should be a win for Black")
+	}
+	for _, symmetry := range board.Symmetries {
+		transformed := game.Transformed(symmetry)
+		if !transformed.IsWin() {
+			t.Errorf("%v turns a win into a non-win", symmetry)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package teeko
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Bitboard for storing piece positions (one bit per square, see Board)
+type Bitboard uint64
+type GameMode int
+
+const (
+	Regular GameMode = iota
+	Advanced
+)
+
+// PHASE enum
+type Phase int
+
+const (
+	DropPhase Phase = iota
+	MovePhase
+)
+
+// Player enum
+type Player int
+
+const (
+	BlackToMove Player = iota
+	RedToMove
+)
+
+// GameMode enum
+func (mode GameMode) String() string {
+	switch mode {
+	case Regular:
+		return "regular"
+	case Advanced:
+		return "advanced"
+	}
+	return "unknown"
+}
+
+var errBadGameMode = errors.New("unknown game mode")
+
+// ParseGameMode reads the names written by GameMode.String
+func ParseGameMode(text string) (GameMode, error) {
+	for _, mode := range []GameMode{Regular, Advanced} {
+		if text == mode.String() {
+			return mode, nil
+		}
+	}
+	return Regular, fmt.Errorf("%w %q", errBadGameMode, text)
+}
+
+// Rules holds everything that decides how a game is played.
+// Games and books carry a pointer to the rules they were made for,
+// so several rule sets can be used side by side in one binary.
+type Rules struct {
+	GameMode GameMode
+	Board    *Board
+	Markers  int // markers per side (4 in standard Teeko)
+
+	// every winning mask that counts under game_mode
+	WinPatterns []Pattern
+}
+
+// Constructor
+func MakeRules(game_mode GameMode, board *Board, markers int) (*Rules, error) {
+	if markers < 1 || 2*markers > board.Size {
+		return nil, fmt.Errorf("%w: %d on a %s board", ErrBadMarkers, markers, board)
+	}
+	if actions := maxActions(board, markers); actions > MAX_ACTIONS {
+		return nil, fmt.Errorf("%w: up to %d per turn", ErrTooManyActions, actions)
+	}
+
+	var rules Rules
+	rules.GameMode = game_mode
+	rules.Board = board
+	rules.Markers = markers
+
+	rules.WinPatterns = append(rules.WinPatterns, board.WinPatterns...)
+	if game_mode == Advanced {
+		rules.WinPatterns = append(rules.WinPatterns, board.AdvancedPatterns...)
+	}
+	return &rules, nil
+}
+
+// String names the rule set; books record it in their header. The last
+// field is a digest of the board's shapes and steps, e.g.
+// "advanced 5x5 4 9f0c...", so variants of one board size differ.
+func (rules *Rules) String() string {
+	return fmt.Sprintf("%s %s %d %016x", rules.GameMode, rules.Board, rules.Markers, rules.Board.digest())
+}
+
+// Teeko struct
+// changes from player_positions to player_positions and occupied_positions
+type Teeko struct {
+	player_positions   Bitboard // squares for "current player"
+	occupied_positions Bitboard // squares occupied by both sides
+	CurrentPlayer      Player
+	Rules              *Rules
+}
+
+// Constructor
+func MakeTeeko(rules *Rules) Teeko {
+	var game Teeko
+	game.player_positions = 0
+	game.occupied_positions = 0
+	game.CurrentPlayer = BlackToMove
+	game.Rules = rules
+	return game
+}
+
+// Figure out the current phase (drop or move)
+func (game *Teeko) Phase() Phase {
+	// Count how many bits are set; once the side to move has
+	// all of its markers on the board => move phase
+	if PopCount(game.player_positions) >= game.Rules.Markers {
+		return MovePhase
+	} else {
+		return DropPhase
+	}
+}
+
+// PositionsOf returns the markers of one colour, whoever is to move
+func (game *Teeko) PositionsOf(colour Player) Bitboard {
+	if colour == game.CurrentPlayer {
+		return game.player_positions
+	}
+	return game.player_positions ^ game.occupied_positions
+}
+
+// Black returns Black's markers
+func (game *Teeko) Black() Bitboard {
+	return game.PositionsOf(BlackToMove)
+}
+
+// Red returns Red's markers
+func (game *Teeko) Red() Bitboard {
+	return game.PositionsOf(RedToMove)
+}
+
+// MarkerCount returns how many markers one colour has on the board
+func (game *Teeko) MarkerCount(colour Player) int {
+	return PopCount(game.PositionsOf(colour))
+}
+
+// Owner returns the colour of the marker on square; false if it is empty
+func (game *Teeko) Owner(square Square) (Player, bool) {
+	bit := game.Rules.Board.SquareBit(square)
+	if (game.occupied_positions & bit) == 0 {
+		return BlackToMove, false
+	}
+	if (game.Black() & bit) != 0 {
+		return BlackToMove, true
+	}
+	return RedToMove, true
+}
+
+// Drop a marker onto the board
+func (game *Teeko) DropMarker(drop Bitboard) {
+	// Toggle current_player
+	if game.CurrentPlayer == BlackToMove {
+		game.CurrentPlayer = RedToMove
+	} else {
+		game.CurrentPlayer = BlackToMove
+	}
+
+	// Swap ownership (original design)
+	game.player_positions ^= game.occupied_positions
+
+	// Add the new drop bit
+	game.occupied_positions |= drop
+}
+
+// Move a marker on the board using a single parameter with two bits set
+func (game *Teeko) MoveMarker(move Bitboard) {
+	// Toggle current_player
+	if game.CurrentPlayer == BlackToMove {
+		game.CurrentPlayer = RedToMove
+	} else {
+		game.CurrentPlayer = BlackToMove
+	}
+
+	// Swap ownership (original design)
+	game.player_positions ^= game.occupied_positions
+
+	// XOR the old and new bits in or out of the occupied_positions
+	game.occupied_positions ^= move
+}
+
+// NullMove passes the turn without touching the board. Passing is not
+// legal in Teeko: use it only in analysis, e.g. to ask whether the side to
+// move already has a winning pattern or for null-move pruning. Playing it
+// twice restores the position.
+func (game *Teeko) NullMove() {
+	game.togglePlayer()
+	game.player_positions ^= game.occupied_positions
+}
+
+// Take back DropMarker(drop); the XOR updates make this cheap
+func (game *Teeko) UndoDropMarker(drop Bitboard) {
+	game.occupied_positions ^= drop
+	game.player_positions ^= game.occupied_positions
+	game.togglePlayer()
+}
+
+// Take back MoveMarker(move)
+func (game *Teeko) UndoMoveMarker(move Bitboard) {
+	game.occupied_positions ^= move
+	game.player_positions ^= game.occupied_positions
+	game.togglePlayer()
+}
+
+// Hand the turn to the other side
+func (game *Teeko) togglePlayer() {
+	if game.CurrentPlayer == BlackToMove {
+		game.CurrentPlayer = RedToMove
+	} else {
+		game.CurrentPlayer = BlackToMove
+	}
+}
+
+// Check if the opponent has a winning shape
+func (game *Teeko) IsWin() bool {
+	_, won := game.WinningPattern()
+	return won
+}
+
+// WinningPattern reports which shape the opponent completed and its squares
+func (game *Teeko) WinningPattern() (Pattern, bool) {
+	// Opponent is everything in occupied_positions except for current player's bits
+	var opponent_positions Bitboard = game.player_positions ^ game.occupied_positions
+
+	// Look the opponent's markers up in the table of winning masks
+	// (the Advanced square corners are only in it under Advanced rules)
+	for _, pattern := range game.Rules.WinPatterns {
+		if (opponent_positions & pattern.Squares) == pattern.Squares {
+			return pattern, true
+		}
+	}
+
+	return Pattern{}, false
+}
+
+// ActionList is a fixed-size buffer of drops or moves, so generating them
+// does not allocate
+type ActionList struct {
+	actions [MAX_ACTIONS]Bitboard
+	count   int
+}
+
+// Room for 8 moves for each of 32 markers, or a drop on each of 64 squares.
+// MakeRules refuses rules that could need more, see maxActions.
+const MAX_ACTIONS int = 8 * BITBOARD_BITS / 2
+
+// maxActions bounds the drops or moves of one turn: every square for a
+// drop, and for a move each marker can reach at most its neighbours and at
+// most the squares it does not cover itself
+func maxActions(board *Board, markers int) int {
+	most_neighbours := 0
+	for _, neighbours := range board.Neighbours {
+		if count := PopCount(neighbours); count > most_neighbours {
+			most_neighbours = count
+		}
+	}
+	moves := markers * most_neighbours
+	if free := markers * (board.Size - markers); free < moves {
+		moves = free
+	}
+	if moves < board.Size {
+		return board.Size
+	}
+	return moves
+}
+
+// Slice returns the generated actions; it points into the buffer
+func (list *ActionList) Slice() []Bitboard {
+	return list.actions[:list.count]
+}
+
+// add appends one action
+func (list *ActionList) add(action Bitboard) {
+	list.actions[list.count] = action
+	list.count++
+}
+
+// PossibleMoves returns all legal "move" bit positions for each marker of current_player
+func (game *Teeko) PossibleMoves() []Bitboard {
+	var list ActionList
+	game.GenerateMoves(&list)
+	return append([]Bitboard(nil), list.Slice()...)
+}
+
+// PossibleDrops returns all empty squares for dropping a new piece
+func (game *Teeko) PossibleDrops() []Bitboard {
+	var list ActionList
+	game.GenerateDrops(&list)
+	return append([]Bitboard(nil), list.Slice()...)
+}
+
+// GenerateMoves fills list with the moves PossibleMoves returns, without allocating
+func (game *Teeko) GenerateMoves(list *ActionList) {
+	board := game.Rules.Board
+
+	var player_positions Bitboard = game.player_positions
+
+	var unoccupied_positions Bitboard = game.occupied_positions ^ board.Mask
+
+	list.count = 0
+
+	for player_positions != 0 {
+		// isolate the least significant set bit
+		var current_marker Bitboard
+		current_marker = player_positions ^ (player_positions & (player_positions - 1))
+		player_positions = player_positions ^ current_marker
+
+		// One AND with the marker's neighbour mask gives every destination
+		var destinations Bitboard
+		destinations = board.Neighbours[bits.TrailingZeros64(uint64(current_marker))] & unoccupied_positions
+		for destinations != 0 {
+			var destination Bitboard
+			destination = destinations ^ (destinations & (destinations - 1))
+			destinations = destinations ^ destination
+			list.add(destination | current_marker)
+		}
+	}
+}
+
+// GenerateDrops fills list with the drops PossibleDrops returns, without allocating
+func (game *Teeko) GenerateDrops(list *ActionList) {
+
+	var empty_positions Bitboard
+	empty_positions = game.occupied_positions ^ game.Rules.Board.Mask
+
+	list.count = 0
+
+	for empty_positions != 0 {
+		var current_position Bitboard
+		current_position = empty_positions ^ (empty_positions & (empty_positions - 1))
+		empty_positions = empty_positions ^ current_position
+		list.add(current_position)
+	}
+}
+
+// Reasons a drop or move can be rejected by ValidateDrop / ValidateMove
+var (
+	ErrGameOver      = errors.New("the game is already won")
+	ErrWrongPhase    = errors.New("wrong phase for this action")
+	ErrNotOneSquare  = errors.New("a drop must name exactly one square")
+	ErrNotTwoSquares = errors.New("a move must name exactly two squares")
+	ErrOffBoard      = errors.New("square is off the board")
+	ErrOccupied      = errors.New("target square is occupied")
+	ErrNotYourMarker = errors.New("no marker of the side to move on the source square")
+	ErrNotAdjacent   = errors.New("target square is not adjacent to the marker")
+)
+
+// ValidateDrop explains why drop is not a legal drop, or returns nil
+func (game *Teeko) ValidateDrop(drop Bitboard) error {
+	if game.IsWin() {
+		return ErrGameOver
+	}
+	if game.Phase() != DropPhase {
+		return ErrWrongPhase
+	}
+	if PopCount(drop) != 1 {
+		return ErrNotOneSquare
+	}
+	if (drop &^ game.Rules.Board.Mask) != 0 {
+		return ErrOffBoard
+	}
+	if (drop & game.occupied_positions) != 0 {
+		return ErrOccupied
+	}
+	var list ActionList
+	game.GenerateDrops(&list)
+	for _, possible_drop := range list.Slice() {
+		if possible_drop == drop {
+			return nil
+		}
+	}
+	return ErrOccupied
+}
+
+// ValidateMove explains why move (source and target bits) is not a legal move, or returns nil
+func (game *Teeko) ValidateMove(move Bitboard) error {
+	if game.IsWin() {
+		return ErrGameOver
+	}
+	if game.Phase() != MovePhase {
+		return ErrWrongPhase
+	}
+	if PopCount(move) != 2 {
+		return ErrNotTwoSquares
+	}
+	if (move &^ game.Rules.Board.Mask) != 0 {
+		return ErrOffBoard
+	}
+
+	// exactly one of the two squares must hold a marker of the side to move
+	var source Bitboard = move & game.player_positions
+	if source == 0 {
+		return ErrNotYourMarker
+	}
+	if source == move || (move&game.occupied_positions) == move {
+		return ErrOccupied
+	}
+
+	var list ActionList
+	game.GenerateMoves(&list)
+	for _, possible_move := range list.Slice() {
+		if possible_move == move {
+			return nil
+		}
+	}
+	return ErrNotAdjacent
+}
+
+// TryDropMarker drops a marker only if the drop is legal
+func (game *Teeko) TryDropMarker(drop Bitboard) error {
+	if err := game.ValidateDrop(drop); err != nil {
+		return err
+	}
+	game.DropMarker(drop)
+	return nil
+}
+
+// TryMoveMarker moves a marker only if the move is legal
+func (game *Teeko) TryMoveMarker(move Bitboard) error {
+	if err := game.ValidateMove(move); err != nil {
+		return err
+	}
+	game.MoveMarker(move)
+	return nil
+}
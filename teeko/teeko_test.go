@@ -0,0 +1,296 @@
+package teeko
+
+import (
+	"reflect"
+	"testing"
+)
+
+// makeBoard builds a board with the standard shapes, failing tb if it is refused
+func makeBoard(tb testing.TB, width, height int) *Board {
+	tb.Helper()
+	board, err := MakeBoard(width, height)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return board
+}
+
+// makeRules builds rules, failing tb if they are refused
+func makeRules(tb testing.TB, mode GameMode, board *Board, markers int) *Rules {
+	tb.Helper()
+	rules, err := MakeRules(mode, board, markers)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return rules
+}
+
+// standardRules returns the rules of standard Advanced Teeko
+func standardRules(tb testing.TB) *Rules {
+	return makeRules(tb, Advanced, makeBoard(tb, 5, 5), 4)
+}
+
+// movePhasePosition drops all eight markers without anyone winning
+func movePhasePosition(tb testing.TB) Teeko {
+	game := MakeTeeko(standardRules(tb))
+	for _, text := range []string{"a1", "b1", "c1", "d1", "e2", "a4", "b4", "d4"} {
+		move, err := ParseMove(text)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		if err := game.PlayMove(move); err != nil {
+			tb.Fatalf("%s: %v", text, err)
+		}
+	}
+	return game
+}
+
+func TestGenerateDoesNotAllocate(t *testing.T) {
+	game := movePhasePosition(t)
+	var list ActionList
+	if allocs := testing.AllocsPerRun(100, func() { game.GenerateMoves(&list) }); allocs != 0 {
+		t.Errorf("GenerateMoves allocates %v times per call", allocs)
+	}
+	empty := MakeTeeko(game.Rules)
+	if allocs := testing.AllocsPerRun(100, func() { empty.GenerateDrops(&list) }); allocs != 0 {
+		t.Errorf("GenerateDrops allocates %v times per call", allocs)
+	}
+}
+
+func TestGenerateMatchesPossible(t *testing.T) {
+	game := movePhasePosition(t)
+	var list ActionList
+	game.GenerateMoves(&list)
+	if got, want := len(list.Slice()), len(game.PossibleMoves()); got != want || got == 0 {
+		t.Errorf("GenerateMoves gives %d moves, PossibleMoves %d", got, want)
+	}
+}
+
+func BenchmarkGenerateMoves(b *testing.B) {
+	game := movePhasePosition(b)
+	var list ActionList
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		game.GenerateMoves(&list)
+	}
+}
+
+func BenchmarkPossibleMoves(b *testing.B) {
+	game := movePhasePosition(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		game.PossibleMoves()
+	}
+}
+
+func BenchmarkGenerateDrops(b *testing.B) {
+	game := MakeTeeko(standardRules(b))
+	var list ActionList
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		game.GenerateDrops(&list)
+	}
+}
+
+func BenchmarkPossibleDrops(b *testing.B) {
+	game := MakeTeeko(standardRules(b))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		game.PossibleDrops()
+	}
+}
+
+// originalIsWin is the hand-written isWin of the original 5x5 engine, kept
+// as an independent check on the masks MakeBoard generates from shapes
+func originalIsWin(opponent_positions Bitboard, advanced bool) bool {
+	const BOARD_LENGTH = 5
+	if ((opponent_positions & (opponent_positions >> 1) & (opponent_positions >> 2) & (opponent_positions >> 3)) &
+		0b0001100011000110001100011) != 0 {
+		return true
+	}
+	if ((opponent_positions &
+		(opponent_positions >> (BOARD_LENGTH * 1)) &
+		(opponent_positions >> (BOARD_LENGTH * 2)) &
+		(opponent_positions >> (BOARD_LENGTH * 3))) &
+		0b0000000000000001111111111) != 0 {
+		return true
+	}
+	if ((opponent_positions &
+		(opponent_positions >> ((BOARD_LENGTH + 1) * 1)) &
+		(opponent_positions >> ((BOARD_LENGTH + 1) * 2)) &
+		(opponent_positions >> ((BOARD_LENGTH + 1) * 3))) &
+		0b0001100011000110001100011) != 0 {
+		return true
+	}
+	if ((opponent_positions &
+		(opponent_positions >> ((BOARD_LENGTH - 1) * 1)) &
+		(opponent_positions >> ((BOARD_LENGTH - 1) * 2)) &
+		(opponent_positions >> ((BOARD_LENGTH - 1) * 3))) &
+		0b1100011000110001100011000) != 0 {
+		return true
+	}
+	if ((opponent_positions &
+		(opponent_positions >> 1) &
+		(opponent_positions >> BOARD_LENGTH) &
+		(opponent_positions >> (BOARD_LENGTH + 1))) &
+		0b011110111101111011110111101111) != 0 {
+		return true
+	}
+	if advanced {
+		var m Bitboard
+		m = opponent_positions & (opponent_positions >> 2) & (opponent_positions >> 10) & (opponent_positions >> 12)
+		if (m & 0b001110011100111001110011100111) != 0 {
+			return true
+		}
+		m = opponent_positions & (opponent_positions >> 3) & (opponent_positions >> 15) & (opponent_positions >> 18)
+		if (m & 0b000110001100011000110001100011) != 0 {
+			return true
+		}
+		if (opponent_positions & 17825809) == 17825809 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIsWinMatchesOriginalMasks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("checks every 5x5 bitboard")
+	}
+	board := makeBoard(t, 5, 5)
+	for _, mode := range []GameMode{Regular, Advanced} {
+		rules := makeRules(t, mode, board, 4)
+		for opponent := Bitboard(0); opponent <= board.Mask; opponent++ {
+			game := MakeRelativePosition(rules, 0, opponent, RedToMove)
+			if game.IsWin() != originalIsWin(opponent, mode == Advanced) {
+				t.Fatalf("%s: IsWin(%025b) = %v, the original masks say otherwise", mode, opponent, game.IsWin())
+			}
+		}
+	}
+}
+
+func TestStandardPatterns(t *testing.T) {
+	board := makeBoard(t, 5, 5)
+	counts := map[PatternKind]int{}
+	for _, pattern := range append(append([]Pattern(nil), board.WinPatterns...), board.AdvancedPatterns...) {
+		counts[pattern.Kind]++
+	}
+	want := map[PatternKind]int{VerticalLine: 10, HorizontalLine: 10, DiagonalLine: 4, AntiDiagonalLine: 4, SmallSquare: 16, SquareCorners: 14}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("5x5 patterns per kind = %v, want %v", counts, want)
+	}
+	if len(board.WinPatterns) != 44 || len(board.AdvancedPatterns) != 14 {
+		t.Errorf("%d regular and %d advanced patterns, want 44 and 14", len(board.WinPatterns), len(board.AdvancedPatterns))
+	}
+
+	rules := makeRules(t, Advanced, board, 4)
+	for _, shape := range []struct {
+		squares []string
+		kind    PatternKind
+		size    int
+	}{
+		{[]string{"b2", "b3", "b4", "b5"}, VerticalLine, 4},
+		{[]string{"a3", "b3", "c3", "d3"}, HorizontalLine, 4},
+		{[]string{"b1", "c2", "d3", "e4"}, DiagonalLine, 4},
+		{[]string{"a4", "b3", "c2", "d1"}, AntiDiagonalLine, 4},
+		{[]string{"c3", "c4", "d3", "d4"}, SmallSquare, 2},
+		{[]string{"a1", "a3", "c1", "c3"}, SquareCorners, 3},
+		{[]string{"b2", "b5", "e2", "e5"}, SquareCorners, 4},
+		{[]string{"a1", "a5", "e1", "e5"}, SquareCorners, 5},
+	} {
+		var squares Bitboard
+		for _, name := range shape.squares {
+			square, err := ParseSquare(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			squares |= board.SquareBit(square)
+		}
+		game := MakeRelativePosition(rules, 0, squares, RedToMove)
+		pattern, won := game.WinningPattern()
+		if !won || pattern.Kind != shape.kind || pattern.Size != shape.size || pattern.Squares != squares {
+			t.Errorf("%v: WinningPattern = %v (%d, %b), %v, want a size %d %v", shape.squares, pattern, pattern.Size, pattern.Squares, won, shape.size, shape.kind)
+		}
+	}
+}
+
+func TestParseGameMode(t *testing.T) {
+	for _, mode := range []GameMode{Regular, Advanced} {
+		if parsed, err := ParseGameMode(mode.String()); err != nil || parsed != mode {
+			t.Errorf("ParseGameMode(%q) = %v, %v", mode.String(), parsed, err)
+		}
+	}
+	if _, err := ParseGameMode("Advanced"); err == nil {
+		t.Error("ParseGameMode accepts \"Advanced\"")
+	}
+}
+
+// squaresOf turns square names into a bitboard of board
+func squaresOf(tb testing.TB, board *Board, names ...string) Bitboard {
+	tb.Helper()
+	var squares Bitboard
+	for _, name := range names {
+		square, err := ParseSquare(name)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		squares |= board.SquareBit(square)
+	}
+	return squares
+}
+
+func TestValidateRejections(t *testing.T) {
+	rules := standardRules(t)
+	board := rules.Board
+	bits := func(names ...string) Bitboard { return squaresOf(t, board, names...) }
+
+	// Black a1 c1, Red b1, Red to move
+	dropping, err := MakePosition(rules, bits("a1", "c1"), bits("b1"), RedToMove)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Black a1 c1 e2 b4, Red b1 d1 a4 d4, Black to move
+	moving := movePhasePosition(t)
+	// Black has just completed a1 b1 c1 d1
+	won, err := MakePosition(rules, bits("a1", "b1", "c1", "d1"), bits("a3", "c3", "e3"), RedToMove)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		game   Teeko
+		drop   bool
+		action Bitboard
+		want   error
+	}{
+		{"legal drop", dropping, true, bits("c3"), nil},
+		{"drop on a marker", dropping, true, bits("a1"), ErrOccupied},
+		{"drop on two squares", dropping, true, bits("c3", "c4"), ErrNotOneSquare},
+		{"drop on no square", dropping, true, 0, ErrNotOneSquare},
+		{"drop off the board", dropping, true, Bitboard(1) << 40, ErrOffBoard},
+		{"drop in the move phase", moving, true, bits("c3"), ErrWrongPhase},
+		{"drop after a win", won, true, bits("e5"), ErrGameOver},
+
+		{"legal move", moving, false, bits("a1", "a2"), nil},
+		{"move in the drop phase", dropping, false, bits("b1", "b2"), ErrWrongPhase},
+		{"move the other side's marker", moving, false, bits("b1", "b2"), ErrNotYourMarker},
+		{"move from an empty square", moving, false, bits("c3", "c4"), ErrNotYourMarker},
+		{"move onto the other side", moving, false, bits("a1", "b1"), ErrOccupied},
+		{"move onto its own marker", moving, false, bits("a1", "c1"), ErrOccupied},
+		{"move too far", moving, false, bits("a1", "a3"), ErrNotAdjacent},
+		{"move with one square", moving, false, bits("a1"), ErrNotTwoSquares},
+		{"move with three squares", moving, false, bits("a1", "a2", "a3"), ErrNotTwoSquares},
+		{"move off the board", moving, false, bits("a1") | Bitboard(1)<<40, ErrOffBoard},
+	} {
+		var err error
+		if test.drop {
+			err = test.game.ValidateDrop(test.action)
+		} else {
+			err = test.game.ValidateMove(test.action)
+		}
+		if err != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package teeko
+
+import (
+	"math/bits"
+)
+
+// ThreatSquares returns the empty squares that would complete a winning
+// pattern for colour if it had a marker there. In the move phase a square
+// only counts as a real threat if a marker can reach it, see ThreatMoves.
+func (game *Teeko) ThreatSquares(colour Player) Bitboard {
+	positions := game.PositionsOf(colour)
+	var threats Bitboard
+	for _, pattern := range game.Rules.WinPatterns {
+		missing := pattern.Squares &^ positions
+		// exactly one square missing and it is empty
+		if missing != 0 && (missing&(missing-1)) == 0 && (missing&game.occupied_positions) == 0 {
+			threats |= missing
+		}
+	}
+	return threats
+}
+
+// GenerateThreats fills list with the drops or moves that win on the spot
+// for colour, as bitboards like GenerateDrops / GenerateMoves
+func (game *Teeko) GenerateThreats(colour Player, list *ActionList) {
+	list.count = 0
+	positions := game.PositionsOf(colour)
+
+	// still dropping: every threat square is a winning drop
+	if PopCount(positions) < game.Rules.Markers {
+		threats := game.ThreatSquares(colour)
+		for threats != 0 {
+			threat := threats & -threats
+			threats ^= threat
+			list.add(threat)
+		}
+		return
+	}
+
+	// moving: a marker next to the missing square that is not itself part
+	// of the pattern completes it
+	neighbours := game.Rules.Board.Neighbours
+	for _, pattern := range game.Rules.WinPatterns {
+		missing := pattern.Squares &^ positions
+		if missing == 0 || (missing&(missing-1)) != 0 || (missing&game.occupied_positions) != 0 {
+			continue
+		}
+		sources := neighbours[bits.TrailingZeros64(uint64(missing))] & positions &^ pattern.Squares
+		for sources != 0 {
+			source := sources & -sources
+			sources ^= source
+			// the same move can complete several patterns
+			move := source | missing
+			duplicate := false
+			for _, action := range list.Slice() {
+				if action == move {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				list.add(move)
+			}
+		}
+	}
+}
+
+// ThreatMoves returns the drops or moves that win on the spot for colour
+func (game *Teeko) ThreatMoves(colour Player) []Move {
+	var list ActionList
+	game.GenerateThreats(colour, &list)
+	board := game.Rules.Board
+	positions := game.PositionsOf(colour)
+
+	moves := make([]Move, 0, list.count)
+	for _, action := range list.Slice() {
+		if PopCount(action) == 1 {
+			moves = append(moves, MakeDrop(board.SquareOf(action)))
+		} else {
+			moves = append(moves, MakeMove(board.SquareOf(action&positions), board.SquareOf(action&^positions)))
+		}
+	}
+	return moves
+}
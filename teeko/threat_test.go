@@ -0,0 +1,104 @@
+package teeko
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// randomPosition places markers at random with counts a game can reach;
+// false if the position is not legal or already won
+func randomPosition(rules *Rules, random *rand.Rand) (Teeko, bool) {
+	black_count := random.Intn(rules.Markers + 1)
+	red_count := black_count
+	to_move := BlackToMove
+	if black_count > 0 && (black_count < rules.Markers || random.Intn(2) == 0) {
+		red_count = black_count - 1
+		to_move = RedToMove
+	}
+	if black_count == rules.Markers && red_count == black_count && random.Intn(2) == 0 {
+		to_move = RedToMove
+	}
+
+	squares := random.Perm(rules.Board.Size)
+	black := ArrayToBitboard(squares[:black_count])
+	red := ArrayToBitboard(squares[black_count : black_count+red_count])
+	game, err := MakePosition(rules, black, red, to_move)
+	return game, err == nil && !game.IsWin()
+}
+
+// bruteForceThreats plays every drop or move of the side to move and keeps
+// the ones that win
+func bruteForceThreats(game Teeko) []Bitboard {
+	var list ActionList
+	drop := game.Phase() == DropPhase
+	if drop {
+		game.GenerateDrops(&list)
+	} else {
+		game.GenerateMoves(&list)
+	}
+	var wins []Bitboard
+	for _, action := range list.Slice() {
+		child := game
+		if drop {
+			child.DropMarker(action)
+		} else {
+			child.MoveMarker(action)
+		}
+		if child.IsWin() {
+			wins = append(wins, action)
+		}
+	}
+	return wins
+}
+
+func sortedThreats(game Teeko, colour Player) []Bitboard {
+	var list ActionList
+	game.GenerateThreats(colour, &list)
+	threats := append([]Bitboard(nil), list.Slice()...)
+	sort.Slice(threats, func(i, j int) bool { return threats[i] < threats[j] })
+	return threats
+}
+
+func TestGenerateThreatsMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	winning := 0
+	for _, mode := range []GameMode{Regular, Advanced} {
+		rules := makeRules(t, mode, makeBoard(t, 5, 5), 4)
+		for tested := 0; tested < 200000; {
+			game, ok := randomPosition(rules, random)
+			if !ok {
+				continue
+			}
+			tested++
+
+			// the side to move, and its opponent by passing the turn
+			passed := game
+			passed.NullMove()
+			for _, check := range []struct {
+				colour   Player
+				position Teeko
+			}{{game.CurrentPlayer, game}, {passed.CurrentPlayer, passed}} {
+				want := bruteForceThreats(check.position)
+				sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+				got := sortedThreats(game, check.colour)
+				if len(want) > 0 {
+					winning++
+				}
+				if len(got) != len(want) {
+					t.Fatalf("%s: black %b red %b: GenerateThreats(%d) = %v, brute force %v",
+						mode, game.Black(), game.Red(), check.colour, got, want)
+				}
+				for i := range got {
+					if got[i] != want[i] {
+						t.Fatalf("%s: black %b red %b: GenerateThreats(%d) = %v, brute force %v",
+							mode, game.Black(), game.Red(), check.colour, got, want)
+					}
+				}
+			}
+		}
+	}
+	if winning == 0 {
+		t.Error("no sampled position had a winning drop or move")
+	}
+}
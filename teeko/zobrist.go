@@ -0,0 +1,60 @@
+package teeko
+
+import (
+	"math/bits"
+)
+
+// Seed for the Zobrist keys so hashes are the same on every run
+const ZOBRIST_SEED uint64 = 0x7ee6c0
+
+// splitMix64 steps state and returns the next pseudo-random number
+func splitMix64(state *uint64) uint64 {
+	*state += 0x9e3779b97f4a7c15
+	z := *state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// buildZobrist fills one random key per (colour, square) and one for Red to move.
+// Called by MakeBoard.
+func (board *Board) buildZobrist() {
+	state := ZOBRIST_SEED
+	for colour := range board.zobrist {
+		board.zobrist[colour] = make([]uint64, board.Size)
+		for index := range board.zobrist[colour] {
+			board.zobrist[colour][index] = splitMix64(&state)
+		}
+	}
+	board.zobrist_red_to_move = splitMix64(&state)
+}
+
+// zobristOf XORs the keys of every square of bb for colour
+func (board *Board) zobristOf(colour Player, bb Bitboard) uint64 {
+	var hash uint64
+	for bb != 0 {
+		hash ^= board.zobrist[colour][bits.TrailingZeros64(uint64(bb))]
+		bb &= bb - 1
+	}
+	return hash
+}
+
+// Hash returns the Zobrist hash of the position, side to move included.
+// It only walks the markers, so it is cheap enough to compute on demand.
+func (game *Teeko) Hash() uint64 {
+	board := game.Rules.Board
+	var hash uint64 = board.zobristOf(BlackToMove, game.Black()) ^ board.zobristOf(RedToMove, game.Red())
+	if game.CurrentPlayer == RedToMove {
+		hash ^= board.zobrist_red_to_move
+	}
+	return hash
+}
+
+// HashAfter updates hash (the hash of game) for playing action, a drop or
+// move bitboard as passed to DropMarker / MoveMarker, without playing it.
+// An empty action is a NullMove and only flips the side to move.
+func (game *Teeko) HashAfter(hash uint64, action Bitboard) uint64 {
+	// a drop adds one square, a move toggles its source and destination:
+	// either way the side to move's key XORs in for every bit of action
+	return hash ^ game.Rules.Board.zobristOf(game.CurrentPlayer, action) ^ game.Rules.Board.zobrist_red_to_move
+}
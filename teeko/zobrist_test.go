@@ -0,0 +1,58 @@
+package teeko
+
+import (
+	"testing"
+)
+
+func TestHashAfterMatchesHash(t *testing.T) {
+	moving := movePhasePosition(t)
+	dropping := MakeTeeko(moving.Rules)
+	if err := dropping.PlayMove(MakeDrop(Square{2, 2})); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, game := range map[string]Teeko{"drop phase": dropping, "move phase": moving} {
+		var list ActionList
+		if game.Phase() == DropPhase {
+			game.GenerateDrops(&list)
+		} else {
+			game.GenerateMoves(&list)
+		}
+		if len(list.Slice()) == 0 {
+			t.Fatalf("%s: no actions", name)
+		}
+		for _, action := range list.Slice() {
+			child := game
+			if game.Phase() == DropPhase {
+				child.DropMarker(action)
+			} else {
+				child.MoveMarker(action)
+			}
+			if got := game.HashAfter(game.Hash(), action); got != child.Hash() {
+				t.Errorf("%s: HashAfter(%b) = %x, Hash after playing it %x", name, action, got, child.Hash())
+			}
+		}
+
+		passed := game
+		passed.NullMove()
+		if got := game.HashAfter(game.Hash(), 0); got != passed.Hash() {
+			t.Errorf("%s: HashAfter of the null move = %x, want %x", name, got, passed.Hash())
+		}
+	}
+}
+
+func TestHashIncludesSideToMove(t *testing.T) {
+	game := movePhasePosition(t)
+	passed := game
+	passed.NullMove()
+	if game.Black() != passed.Black() || game.Red() != passed.Red() {
+		t.Fatal("NullMove changed the markers")
+	}
+	if game.Hash() == passed.Hash() {
+		t.Error("the same markers with the other side to move hash alike")
+	}
+	empty := MakeTeeko(game.Rules)
+	if empty.Hash() != 0 {
+		t.Error("the empty board with Black to move should hash to 0")
+	}
+}